@@ -0,0 +1,62 @@
+package handle
+
+import (
+	"context"
+	"sync"
+)
+
+// Weighted is a weighted semaphore. It is satisfied by *semaphore.Weighted
+// from golang.org/x/sync/semaphore and by the channel based semaphore
+// returned by Chan.
+type Weighted interface {
+	Acquire(ctx context.Context, n int64) error
+	Release(n int64)
+}
+
+// Acquire acquires n from sem, triggering an escape if ctx is done first.
+// The release of n is registered as a cleanup, so it happens in the hatch
+// on both the success and error paths. The returned function releases n
+// early. It is safe to call more than once and makes the cleanup a no-op.
+func Acquire(ctx context.Context, escape *Escape, sem Weighted, n int64) (release func()) {
+	escape.On(sem.Acquire(ctx, n))
+
+	var once sync.Once
+
+	release = func() {
+		once.Do(func() {
+			sem.Release(n)
+		})
+	}
+
+	escape.Cleanup("", release)
+
+	return release
+}
+
+// Chan returns a Weighted backed by ch. The capacity of ch is the total
+// weight of the semaphore.
+func Chan(ch chan struct{}) Weighted {
+	return chanSem(ch)
+}
+
+type chanSem chan struct{}
+
+func (c chanSem) Acquire(ctx context.Context, n int64) error {
+	for i := int64(0); i < n; i++ {
+		select {
+		case c <- struct{}{}:
+		case <-ctx.Done():
+			c.Release(i)
+
+			return context.Cause(ctx)
+		}
+	}
+
+	return nil
+}
+
+func (c chanSem) Release(n int64) {
+	for i := int64(0); i < n; i++ {
+		<-c
+	}
+}
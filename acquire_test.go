@@ -0,0 +1,37 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleAcquire() {
+	sem := handle.Chan(make(chan struct{}, 2))
+
+	f := func(ctx context.Context, n int64) (err error) {
+		escape, hatch := handle.Errorf(&err, "acquire %d", n)
+		defer hatch()
+
+		handle.Acquire(ctx, escape, sem, n)
+
+		fmt.Println("acquired", n)
+
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	fmt.Println(f(ctx, 2))
+	fmt.Println(f(ctx, 2))
+	fmt.Println(f(ctx, 3))
+	// Output:
+	// acquired 2
+	// <nil>
+	// acquired 2
+	// <nil>
+	// acquire 3: context deadline exceeded
+}
@@ -0,0 +1,31 @@
+package handle
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ArgNotNil triggers an escape, with an error of the InvalidArgument kind,
+// if v is nil or a nil pointer, map, slice, channel, function or interface.
+// It gives constructors a uniform, cheap way to reject missing dependencies
+// instead of panicking later:
+//
+//	handle.ArgNotNil(escape, db, "db handle")
+func ArgNotNil(escape Escaper, v interface{}, name string) {
+	if isNil(v) {
+		escape.On(Tag(fmt.Errorf("%s is nil", name), InvalidArgument)) //nolint:goerr113
+	}
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() { //nolint:exhaustive
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,30 @@
+package handle_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) (s *Store, err error) {
+	escape, hatch := handle.Errorf(&err, "new store")
+	defer hatch()
+
+	handle.ArgNotNil(escape, db, "db handle")
+
+	return &Store{db}, nil
+}
+
+func ExampleArgNotNil() {
+	_, err := NewStore(nil)
+	fmt.Println(err)
+	fmt.Println(handle.KindOf(err))
+	// Output:
+	// new store: db handle is nil
+	// invalid argument
+}
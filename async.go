@@ -0,0 +1,86 @@
+package handle
+
+import "sync"
+
+// Async is an Escaper whose On may be called from any goroutine. On never
+// triggers an escape. Instead it queues the error and the owning goroutine
+// applies it, either at a sync point, by calling Check, or when the hatch
+// runs. This suits callback-heavy and event-loop code where errors are
+// reported from goroutines that do not own the escape.
+type Async struct {
+	mu    sync.Mutex
+	errs  []error
+	owner *Escape
+}
+
+var _ Escaper = (*Async)(nil)
+
+// Async returns the Async escaper for s. The hatch applies any errors it
+// has received before calling handlers.
+func (s *Escape) Async() *Async {
+	defer s.lock().Unlock()
+
+	if s.async == nil {
+		s.async = &Async{owner: s}
+	}
+
+	return s.async
+}
+
+// Err returns the first error received since the errors were last applied.
+func (a *Async) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.errs) == 0 {
+		return nil
+	}
+
+	return a.errs[0]
+}
+
+// On queues the non-nil errors in errs, joined, for the owning goroutine.
+func (a *Async) On(errs ...error) {
+	err := join(errs)
+	if err == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.errs = append(a.errs, err)
+}
+
+// Check applies the errors received so far and triggers an escape if there
+// were any. It must only be called by the goroutine that owns the escape.
+func (a *Async) Check() {
+	a.owner.On(a.drain())
+}
+
+// drain returns the errors received so far joined in arrival order.
+func (a *Async) drain() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	errs := a.errs
+	a.errs = nil
+
+	return join(errs)
+}
+
+func (s *Escape) drainAsync() {
+	mu := s.locked()
+	if mu == nil {
+		return
+	}
+
+	a := s.async
+	mu.Unlock()
+
+	if a != nil {
+		if err := a.drain(); err != nil {
+			s.set(err)
+		}
+	}
+}
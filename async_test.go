@@ -0,0 +1,80 @@
+package handle_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleAsync() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "notify")
+		defer hatch()
+
+		async := escape.Async()
+
+		var wg sync.WaitGroup
+
+		for _, name := range names {
+			name := name
+
+			wg.Add(1)
+
+			// A callback invoked on another goroutine.
+			go func() {
+				defer wg.Done()
+
+				if name == "" {
+					_, err := fails(name)
+					async.On(err)
+				}
+			}()
+		}
+
+		wg.Wait()
+		async.Check()
+
+		fmt.Println("all notified")
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b"))
+	fmt.Println(f("a", ""))
+	// Output:
+	// all notified
+	// <nil>
+	// notify: failure
+}
+
+func ExampleAsync_Err() {
+	var async *handle.Async
+
+	f := func(n int) (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		async = escape.Async()
+
+		for i := 1; i <= n; i++ {
+			async.On(fmt.Errorf("callback %d failed", i)) //nolint:goerr113
+		}
+
+		fmt.Println(async.Err())
+
+		return nil
+	}
+
+	// The errors are kept in the order they arrived.
+	lines := strings.Split(f(20).Error(), "\n")
+	fmt.Println(len(lines), lines[len(lines)-1])
+
+	// The hatch applied the errors so none are left.
+	fmt.Println(async.Err())
+	// Output:
+	// callback 1 failed
+	// 20 callback 20 failed
+	// <nil>
+}
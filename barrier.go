@@ -0,0 +1,88 @@
+package handle
+
+import (
+	"strings"
+	"sync"
+)
+
+// BarrierError reports the steps registered with a Barrier that were never
+// marked done.
+type BarrierError struct {
+	Missing []string
+}
+
+func (e *BarrierError) Error() string {
+	return "never finished: " + strings.Join(e.Missing, ", ")
+}
+
+// Barrier tracks the steps a function must complete before it can succeed.
+// It is safe for concurrent use.
+type Barrier struct {
+	mu    sync.Mutex
+	done  map[string]bool
+	steps []string
+}
+
+// Barrier registers steps that must all be marked done before the function
+// returns. If the function would otherwise succeed, the hatch turns any
+// steps that are not done into a *BarrierError, of the Internal kind,
+// catching steps that were skipped silently:
+//
+//	b := escape.Barrier("index", "upload", "notify")
+//	// ...
+//	b.Done("index")
+func (s *Escape) Barrier(steps ...string) *Barrier {
+	b := &Barrier{done: map[string]bool{}, steps: steps}
+
+	mu := s.lock()
+	s.barriers = append(s.barriers, b)
+	mu.Unlock()
+
+	return b
+}
+
+// Done marks step as complete.
+func (b *Barrier) Done(step string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.done[step] = true
+}
+
+func (b *Barrier) missing() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missing []string
+
+	for _, step := range b.steps {
+		if !b.done[step] {
+			missing = append(missing, step)
+		}
+	}
+
+	return missing
+}
+
+func (s *Escape) checkBarriers() {
+	if *s.err != nil {
+		return
+	}
+
+	mu := s.locked()
+	if mu == nil {
+		return
+	}
+
+	barriers := s.barriers
+	mu.Unlock()
+
+	var missing []string
+	for _, b := range barriers {
+		missing = append(missing, b.missing()...)
+	}
+
+	if len(missing) > 0 {
+		*s.err = Tag(&BarrierError{missing}, Internal)
+	}
+}
@@ -0,0 +1,35 @@
+package handle_test
+
+import (
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_Barrier() {
+	f := func(notify bool) (err error) {
+		escape, hatch := handle.Errorf(&err, "publish")
+		defer hatch()
+
+		b := escape.Barrier("index", "upload", "notify")
+
+		b.Done("index")
+		b.Done("upload")
+
+		if notify {
+			b.Done("notify")
+		}
+
+		return nil
+	}
+
+	fmt.Println(f(true))
+
+	err := f(false)
+	fmt.Println(err)
+	fmt.Println(handle.KindOf(err))
+	// Output:
+	// <nil>
+	// publish: never finished: notify
+	// internal
+}
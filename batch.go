@@ -0,0 +1,82 @@
+package handle
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BatchError summarizes the failures of a Batch. It unwraps to the error
+// for each failed item in the order the items were processed.
+type BatchError struct {
+	Failed int
+	Total  int
+	Errs   []error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d items failed, first: %v", e.Failed, e.Total, e.Errs[0])
+}
+
+func (e *BatchError) Unwrap() []error {
+	return e.Errs
+}
+
+// Batch runs a function for each item in a batch and records failures
+// keyed by item ID. A Batch is safe for concurrent use.
+type Batch[K comparable, T any] struct {
+	key      func(T) K
+	mu       sync.Mutex
+	errs     []error
+	failures map[K]error
+	total    int
+}
+
+// NewBatch returns a Batch that uses key to derive the ID of an item:
+//
+//	b := handle.NewBatch(func(r Record) string { return r.ID })
+//	b.Run(records, importRecord)
+//	b.Flush(escape)
+func NewBatch[K comparable, T any](key func(T) K) *Batch[K, T] {
+	return &Batch[K, T]{key: key, failures: map[K]error{}}
+}
+
+// Run calls fn for each of items, recording any error it returns against
+// the ID of the item. Run never triggers an escape.
+func (b *Batch[K, T]) Run(items []T, fn func(T) error) {
+	for _, item := range items {
+		err := fn(item)
+
+		b.mu.Lock()
+
+		b.total++
+		if err != nil {
+			b.errs = append(b.errs, err)
+			b.failures[b.key(item)] = err
+		}
+
+		b.mu.Unlock()
+	}
+}
+
+// Failures returns the errors recorded so far keyed by item ID.
+func (b *Batch[K, T]) Failures() map[K]error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failures := make(map[K]error, len(b.failures))
+	for k, err := range b.failures {
+		failures[k] = err
+	}
+
+	return failures
+}
+
+// Flush triggers an escape with a *BatchError if any item failed.
+func (b *Batch[K, T]) Flush(escape Escaper) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.errs) > 0 {
+		escape.On(&BatchError{len(b.errs), b.total, append([]error{}, b.errs...)})
+	}
+}
@@ -0,0 +1,39 @@
+package handle_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleBatch() {
+	type record struct {
+		id    string
+		count string
+	}
+
+	records := []record{{"a", "1"}, {"b", "two"}, {"c", "3"}}
+
+	b := handle.NewBatch(func(r record) string { return r.id })
+
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "import")
+		defer hatch()
+
+		b.Run(records, func(r record) error {
+			_, err := strconv.Atoi(r.count)
+
+			return err
+		})
+		b.Flush(escape)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(b.Failures()["b"])
+	// Output:
+	// import: 1 of 3 items failed, first: strconv.Atoi: parsing "two": invalid syntax
+	// strconv.Atoi: parsing "two": invalid syntax
+}
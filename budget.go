@@ -0,0 +1,62 @@
+package handle
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BudgetError reports a section that took longer than its budget.
+type BudgetError struct {
+	Label   string
+	Budget  time.Duration
+	Elapsed time.Duration
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%s: took %s, budget %s", e.Label, e.Elapsed, e.Budget)
+}
+
+// Is reports whether target is context.DeadlineExceeded.
+func (e *BudgetError) Is(target error) bool {
+	return target == context.DeadlineExceeded //nolint:errorlint
+}
+
+// WarnOverBudget makes sections that exceed their budget record a warning
+// instead of triggering an escape.
+func WarnOverBudget() Option {
+	return func(s *Escape) {
+		s.budgetWarn = true
+	}
+}
+
+// Budget starts a section, described by label, that is expected to take
+// no longer than d. Call the returned function when the section ends. If
+// the section took longer than d, it triggers an escape with a
+// *BudgetError or, with the WarnOverBudget option, records it as a warning:
+//
+//	done := escape.Budget(200*time.Millisecond, "auth check")
+//	// ...
+//	done()
+//
+// The section is not interrupted when the budget is exceeded.
+func (s *Escape) Budget(d time.Duration, label string) func() {
+	start := time.Now()
+
+	return func() {
+		elapsed := time.Since(start)
+		if elapsed <= d {
+			return
+		}
+
+		err := &BudgetError{Label: label, Budget: d, Elapsed: elapsed}
+
+		if s.budgetWarn {
+			s.record("over budget", err)
+
+			return
+		}
+
+		s.On(err)
+	}
+}
@@ -0,0 +1,35 @@
+package handle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_Budget() {
+	f := func(opts ...handle.Option) (err error) {
+		escape, hatch := handle.With(&err, opts...)
+		defer hatch()
+
+		done := escape.Budget(time.Millisecond, "auth check")
+		time.Sleep(2 * time.Millisecond)
+		done()
+
+		fmt.Println(len(escape.Warnings()), "warnings")
+
+		return nil
+	}
+
+	err := f()
+	fmt.Println(errors.Is(err, context.DeadlineExceeded))
+
+	err = f(handle.WarnOverBudget(), handle.Log(discard()))
+	fmt.Println(err)
+	// Output:
+	// true
+	// 1 warnings
+	// <nil>
+}
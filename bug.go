@@ -0,0 +1,34 @@
+package handle
+
+import "runtime/debug"
+
+// BugError reports an error that should not have been possible.
+type BugError struct {
+	What  string
+	Err   error
+	Stack []byte
+}
+
+func (e *BugError) Error() string {
+	return "bug: " + e.What + ": " + e.Err.Error()
+}
+
+func (e *BugError) Unwrap() error {
+	return e.Err
+}
+
+// Bug triggers an escape, with a *BugError of the Bug kind that records the
+// stack, if err is non-nil. It is for errors that cannot happen, such as
+// compiling a constant regular expression, in code where a panic, as with
+// regexp.MustCompile, would be too blunt:
+//
+//	re, err := regexp.Compile(`^[a-z]+$`)
+//	escape.Bug(err, "compiled regex")
+//
+// It is named Bug, rather than Must, as escape.Must already calls a
+// function.
+func (s *Escape) Bug(err error, what string) {
+	if err != nil {
+		s.On(Tag(&BugError{what, err, debug.Stack()}, Bug))
+	}
+}
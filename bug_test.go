@@ -0,0 +1,36 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_Bug() {
+	f := func(pattern string) (err error) {
+		escape, hatch := handle.Errorf(&err, "match")
+		defer hatch()
+
+		re, err := regexp.Compile(pattern)
+		escape.Bug(err, "compiled regex")
+
+		fmt.Println(re.MatchString("abc"))
+
+		return nil
+	}
+
+	fmt.Println(f(`^[a-z]+$`))
+
+	err := f(`^[a-z+$`)
+	fmt.Println(err)
+
+	var bug *handle.BugError
+	fmt.Println(errors.Is(err, handle.Bug), errors.As(err, &bug) && len(bug.Stack) > 0)
+	// Output:
+	// true
+	// <nil>
+	// match: bug: compiled regex: error parsing regexp: missing closing ]: `[a-z+$`
+	// true true
+}
@@ -0,0 +1,90 @@
+package handle
+
+import (
+	"sync"
+	"time"
+)
+
+// NegativePolicy returns how long to cache err. A duration of zero or less
+// means that err is not cached.
+type NegativePolicy func(err error) time.Duration
+
+// NegativeTTL returns a NegativePolicy that caches errors of any of kinds
+// for ttl and does not cache other errors:
+//
+//	handle.NegativeTTL(5*time.Second, handle.NotFound)
+func NegativeTTL(ttl time.Duration, kinds ...Kind) NegativePolicy {
+	return func(err error) time.Duration {
+		k := KindOf(err)
+
+		for _, kind := range kinds {
+			if k == kind {
+				return ttl
+			}
+		}
+
+		return 0
+	}
+}
+
+// Cache is an in-memory cache of values, and errors, for Cached. Entries
+// are replaced when they expire but are not otherwise evicted, so the keys
+// should be drawn from a bounded set. The zero value is not usable; use
+// NewCache. A Cache is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	entries  map[K]cacheEntry[V]
+	negative NegativePolicy
+	ttl      time.Duration
+}
+
+type cacheEntry[V any] struct {
+	err     error
+	expires time.Time
+	v       V
+}
+
+// NewCache returns a Cache that keeps values for ttl and errors for the
+// duration returned by negative. If negative is nil, errors are not cached.
+func NewCache[K comparable, V any](ttl time.Duration, negative NegativePolicy) *Cache[K, V] {
+	if negative == nil {
+		negative = func(error) time.Duration { return 0 }
+	}
+
+	return &Cache[K, V]{entries: map[K]cacheEntry[V]{}, negative: negative, ttl: ttl}
+}
+
+// Cached returns the value cached in c for key or, if there is none, the
+// value returned by load, which is then cached. An error, whether cached
+// or returned by load, triggers an escape:
+//
+//	u := handle.Cached(escape, users, id, store.User)
+func Cached[K comparable, V any](escape Escaper, c *Cache[K, V], key K, load func(K) (V, error)) V {
+	now := time.Now()
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if !ok || now.After(e.expires) {
+		e.v, e.err = load(key)
+
+		ttl := c.ttl
+		if e.err != nil {
+			ttl = c.negative(e.err)
+		}
+
+		c.mu.Lock()
+		if ttl > 0 {
+			e.expires = now.Add(ttl)
+			c.entries[key] = e
+		} else {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+
+	escape.On(e.err)
+
+	return e.v
+}
@@ -0,0 +1,52 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleCached() {
+	load := func(id string) (string, error) {
+		fmt.Println("load", id)
+
+		switch id {
+		case "ann":
+			return "Ann", nil
+		case "db":
+			return "", handle.Tag(errors.New("connection reset"), handle.Internal)
+		}
+
+		return "", handle.Tag(errors.New("no such user"), handle.NotFound)
+	}
+
+	users := handle.NewCache[string, string](time.Minute, handle.NegativeTTL(time.Minute, handle.NotFound))
+
+	f := func(id string) (err error) {
+		escape, hatch := handle.Errorf(&err, "user %s", id)
+		defer hatch()
+
+		fmt.Println(handle.Cached(escape, users, id, load))
+
+		return nil
+	}
+
+	for _, id := range []string{"ann", "ann", "bob", "bob", "db", "db"} {
+		if err := f(id); err != nil {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// load ann
+	// Ann
+	// Ann
+	// load bob
+	// user bob: no such user
+	// user bob: no such user
+	// load db
+	// user db: connection reset
+	// load db
+	// user db: connection reset
+}
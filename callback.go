@@ -0,0 +1,43 @@
+package handle
+
+import "sync"
+
+// Callback adapts fn into a plain callback, cb, for APIs that do not
+// accept errors, and a flush function. Errors returned by fn are captured
+// rather than passed to escape, as an escape must not be triggered from a
+// goroutine other than the one that deferred the hatch, or from within a
+// third party's stack. Call flush after the API returns to trigger an
+// escape, with the captured errors joined, if fn failed:
+//
+//	cb, flush := handle.Callback(escape, func(m *Message) error {
+//	    return store.Save(ctx, m)
+//	})
+//	sub.Receive(ctx, cb)
+//	flush()
+//
+// The callback is safe for concurrent use.
+func Callback[X any](escape Escaper, fn func(X) error) (cb func(X), flush func()) {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+
+	cb = func(x X) {
+		if err := fn(x); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	}
+
+	flush = func() {
+		mu.Lock()
+		err := join(errs)
+		errs = nil
+		mu.Unlock()
+
+		escape.On(err)
+	}
+
+	return cb, flush
+}
@@ -0,0 +1,45 @@
+package handle_test
+
+import (
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+// each is a third party API that calls fn for each name.
+func each(names []string, fn func(string)) {
+	for _, name := range names {
+		fn(name)
+	}
+}
+
+func ExampleCallback() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "greet all")
+		defer hatch()
+
+		cb, flush := handle.Callback(escape, func(name string) error {
+			greet := works
+			if name == "" {
+				greet = fails
+			}
+
+			s, err := greet(name)
+			fmt.Println(s)
+
+			return err
+		})
+
+		each(names, cb)
+		flush()
+
+		return nil
+	}
+
+	fmt.Println(f("a", "", "b"))
+	// Output:
+	// Hello, a
+	//
+	// Hello, b
+	// greet all: failure
+}
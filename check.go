@@ -0,0 +1,114 @@
+package handle
+
+import "errors"
+
+// Check returns a function that triggers an escape on err or returns v. It
+// collapses the assignment of a value and an error, and the call to On, into
+// a single expression:
+//
+//	f := handle.Check(os.Open(name))(escape)
+//
+// Go only allows a multi-valued call as the sole argument of another call
+// which is why the escape is passed separately.
+func Check[T any](v T, err error) func(Escaper) T {
+	return func(escape Escaper) T {
+		escape.On(err)
+
+		return v
+	}
+}
+
+// Check2 is like Check for calls that return two values and an error.
+func Check2[T1, T2 any](v1 T1, v2 T2, err error) func(Escaper) (T1, T2) {
+	return func(escape Escaper) (T1, T2) {
+		escape.On(err)
+
+		return v1, v2
+	}
+}
+
+// Check3 is like Check for calls that return three values and an error.
+func Check3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) func(Escaper) (T1, T2, T3) {
+	return func(escape Escaper) (T1, T2, T3) {
+		escape.On(err)
+
+		return v1, v2, v3
+	}
+}
+
+// CheckOK is like Check for calls, common in caches, that return a value, a
+// flag and an error:
+//
+//	v, ok := handle.CheckOK(cache.Get(ctx, key))(escape)
+func CheckOK[T any](v T, ok bool, err error) func(Escaper) (T, bool) {
+	return func(escape Escaper) (T, bool) {
+		escape.On(err)
+
+		return v, ok
+	}
+}
+
+// CheckExtra is like Check for calls that return a value, an error and
+// then an extra value, such as the retry-after duration returned by some
+// rate-limited clients:
+//
+//	v, wait := handle.CheckExtra(client.Get(ctx, key))(escape)
+//
+// The extra value is not available to the hatch. Callers that need it on
+// failure should include it in the error.
+func CheckExtra[T, X any](v T, err error, extra X) func(Escaper) (T, X) {
+	return func(escape Escaper) (T, X) {
+		escape.On(err)
+
+		return v, extra
+	}
+}
+
+// Must calls fn and triggers an escape if it returns an error. Unlike the
+// Must functions in the standard library, the failure goes through the
+// hatch, and its wrapping and handler functions, instead of an opaque
+// panic. It is the same as Try.
+func (s *Escape) Must(fn func() error) {
+	s.Try(fn)
+}
+
+// Try calls fn and triggers an escape if it returns an error. It wraps
+// small blocks, such as a commit or flush, without a temporary error
+// variable that would shadow the named return:
+//
+//	escape.Try(tx.Commit)
+func (s *Escape) Try(fn func() error) {
+	s.On(fn())
+}
+
+// Must1 calls fn and triggers an escape if it returns an error. Otherwise
+// it returns the value returned by fn.
+func Must1[T any](escape Escaper, fn func() (T, error)) T {
+	v, err := fn()
+	escape.On(err)
+
+	return v
+}
+
+// OnExceptAs triggers an escape if err is non-nil and no error in its chain
+// is a T. Otherwise it returns the T, and true if err was non-nil, so that
+// the error can be handled locally:
+//
+//	if verr, ok := handle.OnExceptAs[*ValidationError](escape, err); ok {
+//	    // Handle verr.
+//	}
+func OnExceptAs[T error](escape Escaper, err error) (T, bool) {
+	var target T
+
+	if err == nil {
+		return target, false
+	}
+
+	if errors.As(err, &target) {
+		return target, true
+	}
+
+	escape.On(err)
+
+	return target, false
+}
@@ -0,0 +1,184 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleCheck() {
+	sum := func(a, b string) (n int, err error) {
+		escape, hatch := handle.Errorf(&err, "sum(%q, %q)", a, b)
+		defer hatch()
+
+		return handle.Check(strconv.Atoi(a))(escape) + handle.Check(strconv.Atoi(b))(escape), nil
+	}
+
+	fmt.Println(sum("1", "2"))
+	fmt.Println(sum("1", "two"))
+	// Output:
+	// 3 <nil>
+	// 0 sum("1", "two"): strconv.Atoi: parsing "two": invalid syntax
+}
+
+func ExampleCheck2() {
+	split := func(addr string) (err error) {
+		escape, hatch := handle.Errorf(&err, "split(%q)", addr)
+		defer hatch()
+
+		host, port := handle.Check2(net.SplitHostPort(addr))(escape)
+
+		fmt.Println(host, port)
+
+		return nil
+	}
+
+	fmt.Println(split("localhost:8080"))
+	fmt.Println(split("localhost"))
+	// Output:
+	// localhost 8080
+	// <nil>
+	// split("localhost"): address localhost: missing port in address
+}
+
+func ExampleCheckOK() {
+	cache := map[string]int{"a": 1}
+
+	get := func(key string) (int, bool, error) {
+		if key == "" {
+			return 0, false, errors.New("empty key")
+		}
+
+		v, ok := cache[key]
+
+		return v, ok, nil
+	}
+
+	f := func(key string) (err error) {
+		escape, hatch := handle.Errorf(&err, "f(%q)", key)
+		defer hatch()
+
+		v, ok := handle.CheckOK(get(key))(escape)
+
+		fmt.Println(v, ok)
+
+		return nil
+	}
+
+	fmt.Println(f("a"))
+	fmt.Println(f("b"))
+	fmt.Println(f(""))
+	// Output:
+	// 1 true
+	// <nil>
+	// 0 false
+	// <nil>
+	// f(""): empty key
+}
+
+func ExampleCheckExtra() {
+	fetch := func(key string) (string, error, time.Duration) { //nolint:stylecheck
+		if key == "hot" {
+			return "", errors.New("rate limited"), time.Second
+		}
+
+		return "value", nil, 0
+	}
+
+	f := func(key string) (err error) {
+		escape, hatch := handle.Errorf(&err, "f(%q)", key)
+		defer hatch()
+
+		v, wait := handle.CheckExtra(fetch(key))(escape)
+
+		fmt.Println(v, wait)
+
+		return nil
+	}
+
+	fmt.Println(f("cold"))
+	fmt.Println(f("hot"))
+	// Output:
+	// value 0s
+	// <nil>
+	// f("hot"): rate limited
+}
+
+func ExampleMust1() {
+	f := func(pattern string) (err error) {
+		escape, hatch := handle.Errorf(&err, "f(%q)", pattern)
+		defer hatch()
+
+		re := handle.Must1(escape, func() (*regexp.Regexp, error) {
+			return regexp.Compile(pattern)
+		})
+
+		escape.Must(func() error {
+			if !re.MatchString("World!") {
+				return errFailure
+			}
+
+			return nil
+		})
+
+		fmt.Println("matched")
+
+		return nil
+	}
+
+	fmt.Println(f("W.*d"))
+	fmt.Println(f("x"))
+	fmt.Println(f("("))
+	// Output:
+	// matched
+	// <nil>
+	// f("x"): failure
+	// f("("): error parsing regexp: missing closing ): `(`
+}
+
+func ExampleEscape_Try() {
+	flush := func() error {
+		return errFailure
+	}
+
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "save")
+		defer hatch()
+
+		escape.Try(func() error {
+			_, err := works("World!")
+			return err
+		})
+
+		escape.Try(flush)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: save: failure
+}
+
+func ExampleOnExceptAs() {
+	f := func(s string) (err error) {
+		escape, hatch := handle.Errorf(&err, "f(%q)", s)
+		defer hatch()
+
+		_, err = strconv.Atoi(s)
+		if nerr, ok := handle.OnExceptAs[*strconv.NumError](escape, err); ok {
+			fmt.Println("handled:", nerr.Func)
+		}
+
+		return nil
+	}
+
+	fmt.Println(f("x"))
+	// Output:
+	// handled: Atoi
+	// <nil>
+}
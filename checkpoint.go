@@ -0,0 +1,99 @@
+package handle
+
+import "time"
+
+// CheckpointError is the error returned by the hatch when an escape is
+// triggered after a checkpoint.
+type CheckpointError struct {
+	Label string
+	Err   error
+}
+
+func (e *CheckpointError) Error() string {
+	return "while " + e.Label + ": " + e.Err.Error()
+}
+
+func (e *CheckpointError) Unwrap() error {
+	return e.Err
+}
+
+// Checkpoint records label as the step the function is performing. If the
+// function fails, the hatch wraps the error with the label of the last
+// checkpoint, before calling any handlers, so that a function with many
+// steps produces messages like
+//
+//	copy src dst: while parsing header: unexpected EOF
+//
+// without wrapping each error individually.
+func (s *Escape) Checkpoint(label string) {
+	if s.timed {
+		now := time.Now()
+		s.endStep(now)
+		s.stepStart = now
+	}
+
+	s.checkpoint = label
+}
+
+// StepTiming is the time taken by the step started by a checkpoint.
+type StepTiming struct {
+	Label    string
+	Duration time.Duration
+}
+
+// TimingError carries the time taken by each step of a failed function.
+// The last step is the one that failed. It does not change the message of
+// the error it wraps.
+type TimingError struct {
+	Err   error
+	Steps []StepTiming
+}
+
+func (e *TimingError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TimingError) Unwrap() error {
+	return e.Err
+}
+
+// Timed records how long each step takes. A step starts with a call to
+// Checkpoint and ends with the next call or with the hatch.
+// On failure the hatch wraps the error in a *TimingError so that failures
+// caused by slow steps can be told apart from logic failures. On success
+// the timings are available from Timings.
+func Timed() Option {
+	return func(s *Escape) {
+		s.timed = true
+	}
+}
+
+// Timings returns the time taken by each step completed so far. Once the
+// hatch has run, this includes the last step.
+func (s *Escape) Timings() []StepTiming {
+	return append([]StepTiming{}, s.steps...)
+}
+
+func (s *Escape) checkpointed() {
+	if s.timed {
+		s.endStep(time.Now())
+	}
+
+	if *s.err == nil {
+		return
+	}
+
+	if s.checkpoint != "" {
+		*s.err = &CheckpointError{s.checkpoint, *s.err}
+	}
+
+	if s.timed {
+		*s.err = &TimingError{*s.err, s.Timings()}
+	}
+}
+
+func (s *Escape) endStep(now time.Time) {
+	if s.checkpoint != "" {
+		s.steps = append(s.steps, StepTiming{s.checkpoint, now.Sub(s.stepStart)})
+	}
+}
@@ -0,0 +1,56 @@
+package handle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_Checkpoint() {
+	f := func(src, dst string) (err error) {
+		escape, hatch := handle.Errorf(&err, "copy %s %s", src, dst)
+		defer hatch()
+
+		escape.Checkpoint("reading header")
+		_, err = works(src)
+		escape.On(err)
+
+		escape.Checkpoint("parsing header")
+		escape.On(io.ErrUnexpectedEOF)
+
+		return nil
+	}
+
+	fmt.Println(f("src", "dst"))
+	// Output: copy src dst: while parsing header: unexpected EOF
+}
+
+func ExampleTimed() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err, handle.Timed())
+		defer hatch()
+
+		escape.Checkpoint("connect")
+		escape.Checkpoint("query")
+		escape.On(context.DeadlineExceeded)
+
+		return nil
+	}
+
+	err := f()
+	fmt.Println(err)
+
+	var terr *handle.TimingError
+	if errors.As(err, &terr) {
+		for _, step := range terr.Steps {
+			fmt.Println(step.Label)
+		}
+	}
+	// Output:
+	// while query: context deadline exceeded
+	// connect
+	// query
+}
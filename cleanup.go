@@ -0,0 +1,75 @@
+package handle
+
+import "context"
+
+// CleanupOrder declares the order in which cleanup groups run. Groups not
+// listed run after those that are, in the order they were first used.
+func CleanupOrder(groups ...string) Option {
+	return func(s *Escape) {
+		s.order = append(s.order, groups...)
+	}
+}
+
+// Cleanup registers fn to be called by the hatch, on both the success and
+// error paths, as part of group. Groups run in the order declared by the
+// CleanupOrder option and, within a group, functions are called in reverse
+// order of registration to match the LIFO order of deferred functions.
+// Cleanups run before any handler functions.
+func (s *Escape) Cleanup(group string, fn func()) {
+	defer s.lock().Unlock()
+
+	s.cleanups = append(s.cleanups, cleanup{group, fn})
+}
+
+// CleanupAfter arranges for the cleanups registered with the escape to run
+// if ctx is done before the hatch runs, for example because the goroutine
+// is parked waiting on something that will never happen. Each cleanup runs
+// exactly once, either when ctx is done or in the hatch. Cleanups that run
+// because ctx is done run on another goroutine while the function may still
+// be using the resources they release, so they must be safe to call
+// concurrently with it.
+func (s *Escape) CleanupAfter(ctx context.Context) {
+	stop := context.AfterFunc(ctx, s.cleanup)
+
+	s.exit = append(s.exit, func() {
+		stop()
+	})
+}
+
+type cleanup struct {
+	group string
+	fn    func()
+}
+
+func (s *Escape) cleanup() {
+	mu := s.locked()
+	if mu == nil {
+		return
+	}
+
+	cleanups := s.cleanups
+	s.cleanups = nil
+	mu.Unlock()
+
+	order := append([]string{}, s.order...)
+	seen := map[string]bool{}
+
+	for _, g := range order {
+		seen[g] = true
+	}
+
+	for _, c := range cleanups {
+		if !seen[c.group] {
+			seen[c.group] = true
+			order = append(order, c.group)
+		}
+	}
+
+	for _, g := range order {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			if c := cleanups[i]; c.group == g {
+				c.fn()
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_Cleanup() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.CleanupOrder("flush", "close", "delete-temp"),
+		)
+		defer hatch()
+
+		escape.Cleanup("delete-temp", func() { fmt.Println("delete temp") })
+		escape.Cleanup("close", func() { fmt.Println("close a") })
+		escape.Cleanup("flush", func() { fmt.Println("flush") })
+		escape.Cleanup("close", func() { fmt.Println("close b") })
+		escape.Cleanup("", func() { fmt.Println("ungrouped") })
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// flush
+	// close b
+	// close a
+	// delete temp
+	// ungrouped
+	// failure
+}
+
+func ExampleEscape_CleanupAfter() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cleaned := make(chan struct{})
+
+	f := func() (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		escape.CleanupAfter(ctx)
+
+		escape.Cleanup("", func() {
+			fmt.Println("cleanup")
+			close(cleaned)
+		})
+
+		// Parked until the cleanup has run.
+		<-cleaned
+
+		return nil
+	}
+
+	go cancel()
+
+	fmt.Println(f())
+	// Output:
+	// cleanup
+	// <nil>
+}
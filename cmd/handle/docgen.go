@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+// docgen writes to w the reference for the Kinds in the registry and the
+// packages under dirs. As with go/build, files excluded by build
+// constraints and files that cannot be parsed are skipped.
+func docgen(escape handle.Escaper, w io.Writer, dirs []string) {
+	fset := token.NewFileSet()
+
+	var files []*ast.File
+
+	for _, dir := range dirs {
+		escape.On(filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			name := d.Name()
+			if d.IsDir() {
+				if path != dir && (name == "testdata" || name == "vendor" ||
+					strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				return nil
+			}
+
+			if ok, _ := build.Default.MatchFile(filepath.Dir(path), name); !ok {
+				return nil
+			}
+
+			if file, err := parser.ParseFile(fset, path, nil, 0); err == nil {
+				files = append(files, file)
+			}
+
+			return nil
+		}))
+	}
+
+	_, err := w.Write(handle.Check(reference(fset, files))(escape))
+	escape.On(err)
+}
+
+type kindRow struct {
+	code, kind, http, grpc, description, remediation string
+}
+
+type opRow struct {
+	name, at string
+}
+
+// reference returns, as markdown, a table of the Kinds in the registry and
+// those declared by handle.KindInfo literals in files, followed by a table
+// of the operations named in calls to handle.Errorf, handle.Wrapf and
+// handle.Op. Kinds in the registry take precedence over declarations with
+// the same code. Values that are not constants declared in files appear as
+// written.
+func reference(fset *token.FileSet, files []*ast.File) (src []byte, err error) {
+	escape, hatch := handle.Errorf(&err, "docgen")
+	defer hatch()
+
+	kinds := map[string]kindRow{}
+
+	for _, info := range handle.Registry().Kinds() {
+		kinds[info.Code] = kindRow{
+			info.Code, string(info.Kind), number(info.HTTPStatus), number(info.GRPCCode),
+			info.Description, info.Remediation,
+		}
+	}
+
+	consts := map[string]string{}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				for i, name := range vs.Names {
+					if i < len(vs.Values) {
+						if lit, ok := vs.Values[i].(*ast.BasicLit); ok {
+							consts[name.Name] = literal(lit)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	value := func(expr ast.Expr) string {
+		switch v := expr.(type) {
+		case *ast.BasicLit:
+			return literal(v)
+		case *ast.Ident:
+			if s, ok := consts[v.Name]; ok {
+				return s
+			}
+		}
+
+		var b bytes.Buffer
+
+		escape.On(printer.Fprint(&b, fset, expr))
+
+		return b.String()
+	}
+
+	var ops []opRow
+
+	for _, file := range files {
+		pkg := handleName(file)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CompositeLit:
+				if !isSelector(n.Type, pkg, "KindInfo") {
+					break
+				}
+
+				fields := map[string]string{}
+
+				for _, elt := range n.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if key, ok := kv.Key.(*ast.Ident); ok {
+							fields[key.Name] = value(kv.Value)
+						}
+					}
+				}
+
+				code := fields["Code"]
+				if _, ok := kinds[code]; !ok && code != "" {
+					kinds[code] = kindRow{
+						code, fields["Kind"], fields["HTTPStatus"], fields["GRPCCode"],
+						fields["Description"], fields["Remediation"],
+					}
+				}
+			case *ast.CallExpr:
+				arg := -1
+
+				switch {
+				case isSelector(n.Fun, pkg, "Errorf"):
+					arg = 1
+				case isSelector(n.Fun, pkg, "Op"), isSelector(n.Fun, pkg, "Wrapf"):
+					arg = 0
+				}
+
+				if arg >= 0 && arg < len(n.Args) {
+					if lit, ok := n.Args[arg].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						pos := fset.Position(n.Pos())
+						ops = append(ops, opRow{literal(lit), pos.Filename + ":" + strconv.Itoa(pos.Line)})
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	rows := make([]kindRow, 0, len(kinds))
+	for _, row := range kinds {
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].code < rows[j].code
+	})
+
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].name < ops[j].name
+	})
+
+	var out bytes.Buffer
+
+	out.WriteString("<!-- Code generated by handle docgen. DO NOT EDIT. -->\n\n# Errors\n\n")
+	out.WriteString("| Code | Kind | HTTP | gRPC | Description | Remediation |\n")
+	out.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+
+	for _, r := range rows {
+		fmt.Fprintf(&out, "| %s | %s | %s | %s | %s | %s |\n",
+			cell(r.code), cell(r.kind), cell(r.http), cell(r.grpc), cell(r.description), cell(r.remediation))
+	}
+
+	if len(ops) > 0 {
+		out.WriteString("\n## Operations\n\n| Operation | Location |\n| --- | --- |\n")
+
+		for _, op := range ops {
+			fmt.Fprintf(&out, "| %s | %s |\n", cell(op.name), cell(op.at))
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// cell escapes s for use in a markdown table cell.
+func cell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func literal(lit *ast.BasicLit) string {
+	if lit.Kind == token.STRING {
+		if s, err := strconv.Unquote(lit.Value); err == nil {
+			return s
+		}
+	}
+
+	return lit.Value
+}
+
+func number(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+const declared = `package orders
+
+import (
+	"net/http"
+
+	h "github.com/michaelmacinnis/handle"
+)
+
+const Conflict h.Kind = "conflict"
+
+func init() {
+	h.Registry().Register(h.KindInfo{
+		Kind:        Conflict,
+		Code:        "CONFLICT",
+		Description: "The order was changed | by another request.",
+		HTTPStatus:  http.StatusConflict,
+		GRPCCode:    10,
+	})
+}
+
+func Place(id string) (err error) {
+	escape, hatch := h.With(&err, h.Op("orders.place"), h.Wrapf("place order %s", id))
+	defer hatch()
+
+	escape.On(h.Tag(save(id), Conflict))
+
+	return nil
+}
+`
+
+const documented = `<!-- Code generated by handle docgen. DO NOT EDIT. -->
+
+# Errors
+
+| Code | Kind | HTTP | gRPC | Description | Remediation |
+| --- | --- | --- | --- | --- | --- |
+| BUG | bug | 500 | 13 | An error that should not have been possible occurred. | Report this, with the error and stack, to the maintainers. |
+| CONFLICT | conflict | http.StatusConflict | 10 | The order was changed \| by another request. |  |
+| INTERNAL | internal | 500 | 13 | An unexpected condition was encountered. | Retry later. If the problem persists, report it. |
+| INVALID_ARGUMENT | invalid argument | 400 | 3 | The caller supplied an invalid argument. | Check the arguments and try again. |
+| NOT_FOUND | not found | 404 | 5 | The requested entity was not found. | Check that the name or ID is correct and that it exists. |
+| RESOURCE_EXHAUSTED | resource exhausted | 429 | 8 | A rate limit or quota was exceeded. | Wait before retrying or request a higher quota. |
+
+## Operations
+
+| Operation | Location |
+| --- | --- |
+| orders.place | orders.go:22 |
+| place order %s | orders.go:22 |
+`
+
+func TestReference(t *testing.T) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "orders.go", declared, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := reference(fset, []*ast.File{file})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(src) != documented {
+		t.Errorf("got:\n%s\nwant:\n%s", src, documented)
+	}
+}
+
+func TestDocgenSkipsFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, src := range map[string]string{
+		"orders.go":  declared,
+		"broken.go":  "package orders\n\nfunc {",
+		"ignored.go": "//go:build ignore\n\npackage orders\n\nvar _ = h.Op(\"ignored\")\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var b bytes.Buffer
+
+	err := func() (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		docgen(escape, &b, []string{dir})
+
+		return nil
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out := b.String(); !strings.Contains(out, "| orders.place |") || strings.Contains(out, "ignored") {
+		t.Errorf("unexpected reference:\n%s", out)
+	}
+}
@@ -0,0 +1,90 @@
+// Command handle generates code for programs that use the handle package.
+//
+// Usage:
+//
+//	handle wire [dir ...]
+//	handle docgen [dir ...]
+//
+// Wire writes, to handle_wire.go in each directory (by default the current
+// directory), a wrapper for each function annotated with a directive of
+// the form
+//
+//	//handle:wire Name [option, ...]
+//
+// The annotated function must take an escape, a *handle.Escape or a
+// handle.Escaper, as its first parameter and return an error as its last
+// result. The wrapper, called Name, takes the remaining parameters, creates
+// the escape and hatch, passing the options to handle.With, and calls the
+// annotated function. The options can refer to the parameters:
+//
+//	//handle:wire CopyFile handle.Wrapf("copy %s %s", src, dst)
+//	func copyFile(escape *handle.Escape, src, dst string) error {
+//	    r := handle.Check(os.Open(src))(escape)
+//	    // ...
+//	}
+//
+// A go:generate line keeps the wrappers up to date:
+//
+//	//go:generate go run github.com/michaelmacinnis/handle/cmd/handle wire
+//
+// Docgen writes to standard output a markdown reference of the errors a
+// service can return: the Kinds registered by the handle package and those
+// declared with handle.KindInfo literals in the packages in and under each
+// directory (by default the current directory), with their codes, HTTP
+// statuses, gRPC codes, descriptions and remediations, followed by the
+// operations named in calls to handle.Errorf, handle.Wrapf and handle.Op
+// and where they are made. Files excluded by build constraints or that
+// cannot be parsed are skipped. Checking in the output of a go:generate
+// line keeps API error documentation in sync with the code:
+//
+//	//go:generate sh -c "go run github.com/michaelmacinnis/handle/cmd/handle docgen . > ERRORS.md"
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/michaelmacinnis/handle"
+	"github.com/michaelmacinnis/handle/flagx"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	fs := flag.NewFlagSet("handle", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: handle wire [dir ...]")
+		fmt.Fprintln(fs.Output(), "       handle docgen [dir ...]")
+	}
+
+	err := func() (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		flagx.Parse(escape, fs, args)
+		flagx.Args(escape, fs, 1, -1)
+
+		dirs := fs.Args()[1:]
+		if len(dirs) == 0 {
+			dirs = []string{"."}
+		}
+
+		switch cmd := fs.Arg(0); cmd {
+		case "wire":
+			for _, dir := range dirs {
+				wire(escape, dir)
+			}
+		case "docgen":
+			docgen(escape, os.Stdout, dirs)
+		default:
+			escape.On(&flagx.UsageError{Err: fmt.Errorf("unknown command %q", cmd)}) //nolint:goerr113
+		}
+
+		return nil
+	}()
+
+	return flagx.Report(fs, err)
+}
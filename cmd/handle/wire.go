@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+const (
+	directive  = "//handle:wire"
+	handlePath = "github.com/michaelmacinnis/handle"
+	wireFile   = "handle_wire.go"
+)
+
+var (
+	errNoEscape = errors.New("first parameter must be a *handle.Escape or handle.Escaper")
+	errNoError  = errors.New("last result must be an error")
+	errNoName   = errors.New("missing wrapper name")
+	errTypeArgs = errors.New("type parameters are not supported")
+)
+
+// wire writes the wrappers for the annotated functions in the package in
+// dir to the wire file in dir.
+func wire(escape handle.Escaper, dir string) {
+	fset := token.NewFileSet()
+
+	var files []*ast.File
+
+	for _, entry := range handle.Check(os.ReadDir(dir))(escape) {
+		name := entry.Name()
+		if entry.IsDir() || name == wireFile ||
+			!strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		files = append(files, handle.Check(parser.ParseFile(fset, path, nil, parser.ParseComments))(escape))
+	}
+
+	src := handle.Check(generate(fset, files))(escape)
+	if src != nil {
+		escape.On(os.WriteFile(filepath.Join(dir, wireFile), src, 0o644)) //nolint:gosec
+	}
+}
+
+// generate returns the source of the wire file for files, which must be
+// from the same package, or nil if no function is annotated.
+func generate(fset *token.FileSet, files []*ast.File) (src []byte, err error) {
+	escape, hatch := handle.Errorf(&err, "wire")
+	defer hatch()
+
+	var (
+		body    bytes.Buffer
+		imports = map[string]*ast.ImportSpec{}
+		pkg     string
+	)
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+
+			for _, c := range fn.Doc.List {
+				if c.Text == directive || strings.HasPrefix(c.Text, directive+" ") {
+					pkg = file.Name.Name
+					wrapper(escape, &body, fset, file, fn, strings.TrimPrefix(c.Text, directive))
+				}
+			}
+		}
+
+		for _, spec := range file.Imports {
+			imports[importName(spec)] = spec
+		}
+	}
+
+	if pkg == "" {
+		return nil, nil
+	}
+
+	var out bytes.Buffer
+
+	fmt.Fprintf(&out, "// Code generated by handle wire. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+
+	// Import the packages referred to by the wrappers.
+	probe := handle.Check(parser.ParseFile(token.NewFileSet(), "", "package p\n"+body.String(), 0))(escape)
+
+	var used []string
+
+	for _, id := range probe.Unresolved {
+		if spec, ok := imports[id.Name]; ok {
+			line := spec.Path.Value
+			if spec.Name != nil {
+				line = spec.Name.Name + " " + line
+			}
+
+			used = append(used, line)
+
+			delete(imports, id.Name)
+		}
+	}
+
+	sort.Strings(used)
+
+	if len(used) > 0 {
+		fmt.Fprintf(&out, "import (\n\t%s\n)\n", strings.Join(used, "\n\t"))
+	}
+
+	out.Write(body.Bytes())
+
+	return handle.Check(format.Source(out.Bytes()))(escape), nil
+}
+
+// wrapper writes to w the wrapper for fn described by the text following
+// the directive.
+func wrapper(escape handle.Escaper, w *bytes.Buffer, fset *token.FileSet, file *ast.File, fn *ast.FuncDecl, text string) {
+	name, opts, _ := strings.Cut(strings.TrimSpace(text), " ")
+
+	at := func(err error) error {
+		return fmt.Errorf("%s: %s: %w", fset.Position(fn.Pos()), fn.Name.Name, err)
+	}
+
+	if name == "" {
+		escape.On(at(errNoName))
+	}
+
+	if fn.Type.TypeParams != nil {
+		escape.On(at(errTypeArgs))
+	}
+
+	params := fields(fn.Type.Params)
+	if len(params) == 0 || !isEscape(file, params[0].typ) {
+		escape.On(at(errNoEscape))
+	}
+
+	results := fields(fn.Type.Results)
+	if len(results) == 0 || !isError(results[len(results)-1].typ) {
+		escape.On(at(errNoError))
+	}
+
+	typ := func(expr ast.Expr) string {
+		var b bytes.Buffer
+
+		escape.On(printer.Fprint(&b, fset, expr))
+
+		return b.String()
+	}
+
+	// Name the parameters so that the wrapper can pass them on, avoiding
+	// the names used by the wrapper itself.
+	var decls, args []string
+
+	for i, p := range params[1:] {
+		if p.name == "" || p.name == "_" || p.name == "escape" || p.name == "hatch" || p.name == "err" {
+			p.name = "p" + strconv.Itoa(i+1)
+		}
+
+		decls = append(decls, p.name+" "+typ(p.typ))
+
+		arg := p.name
+		if _, ok := p.typ.(*ast.Ellipsis); ok {
+			arg += "..."
+		}
+
+		args = append(args, arg)
+	}
+
+	var outs []string
+
+	for i, r := range results[:len(results)-1] {
+		outs = append(outs, "r"+strconv.Itoa(i)+" "+typ(r.typ))
+	}
+
+	outs = append(outs, "err error")
+
+	recv, call := "", fn.Name.Name
+	if fn.Recv != nil {
+		r := fields(fn.Recv)[0]
+		if r.name == "" || r.name == "_" {
+			r.name = "r"
+		}
+
+		recv = "(" + r.name + " " + typ(r.typ) + ") "
+		call = r.name + "." + call
+	}
+
+	with := "&err"
+	if opts != "" {
+		with += ", " + opts
+	}
+
+	fmt.Fprintf(w, "\n// %s calls %s with an escape and hatch.\n", name, fn.Name.Name)
+	fmt.Fprintf(w, "func %s%s(%s) (%s) {\n", recv, name, strings.Join(decls, ", "), strings.Join(outs, ", "))
+	fmt.Fprintf(w, "\tescape, hatch := %s.With(%s)\n\tdefer hatch()\n\n", handleName(file), with)
+	fmt.Fprintf(w, "\treturn %s(%s)\n}\n", call, strings.Join(append([]string{"escape"}, args...), ", "))
+}
+
+type field struct {
+	name string
+	typ  ast.Expr
+}
+
+// fields flattens list so that each field has at most one name.
+func fields(list *ast.FieldList) []*field {
+	if list == nil {
+		return nil
+	}
+
+	var fs []*field
+
+	for _, f := range list.List {
+		if len(f.Names) == 0 {
+			fs = append(fs, &field{"", f.Type})
+		}
+
+		for _, n := range f.Names {
+			fs = append(fs, &field{n.Name, f.Type})
+		}
+	}
+
+	return fs
+}
+
+func isError(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+
+	return ok && id.Name == "error"
+}
+
+func isEscape(file *ast.File, expr ast.Expr) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return isSelector(star.X, handleName(file), "Escape")
+	}
+
+	return isSelector(expr, handleName(file), "Escaper")
+}
+
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+
+	id, ok := sel.X.(*ast.Ident)
+
+	return ok && id.Name == pkg
+}
+
+// handleName returns the name by which file refers to the handle package.
+func handleName(file *ast.File) string {
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path == handlePath {
+			return importName(spec)
+		}
+	}
+
+	return "handle"
+}
+
+var version = regexp.MustCompile(`^v[0-9]+$`)
+
+// importName returns the name by which spec is referred to.
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	path, _ := strconv.Unquote(spec.Path.Value)
+	elems := strings.Split(path, "/")
+
+	name := elems[len(elems)-1]
+	if version.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+
+	return name
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+const annotated = `package copy
+
+import (
+	"io"
+	"os"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+//handle:wire CopyFile handle.Wrapf("copy %s %s", src, dst)
+func copyFile(escape *handle.Escape, src, dst string) error {
+	return nil
+}
+
+type Copier struct{}
+
+//handle:wire Copy
+func (c *Copier) copy(escape handle.Escaper, w io.Writer, r io.Reader, bufs ...[]byte) (int64, error) {
+	return 0, nil
+}
+
+func unannotated(f *os.File) {}
+`
+
+const generated = `// Code generated by handle wire. DO NOT EDIT.
+
+package copy
+
+import (
+	"github.com/michaelmacinnis/handle"
+	"io"
+)
+
+// CopyFile calls copyFile with an escape and hatch.
+func CopyFile(src string, dst string) (err error) {
+	escape, hatch := handle.With(&err, handle.Wrapf("copy %s %s", src, dst))
+	defer hatch()
+
+	return copyFile(escape, src, dst)
+}
+
+// Copy calls copy with an escape and hatch.
+func (c *Copier) Copy(w io.Writer, r io.Reader, bufs ...[]byte) (r0 int64, err error) {
+	escape, hatch := handle.With(&err)
+	defer hatch()
+
+	return c.copy(escape, w, r, bufs...)
+}
+`
+
+func TestGenerate(t *testing.T) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "copy.go", annotated, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := generate(fset, []*ast.File{file})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(src) != generated {
+		t.Errorf("got:\n%s\nwant:\n%s", src, generated)
+	}
+}
+
+func TestGenerateNoEscape(t *testing.T) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "bad.go", `package bad
+
+//handle:wire Bad
+func bad(s string) error { return nil }
+`, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "wire: bad.go:4:1: bad: first parameter must be a *handle.Escape or handle.Escaper"
+	if _, err := generate(fset, []*ast.File{file}); err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+}
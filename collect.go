@@ -0,0 +1,86 @@
+package handle
+
+import "sync"
+
+// Collect accumulates errors without triggering an escape. A batch job can
+// call On for each item and then Flush to fail once every item has been
+// processed. Collect satisfies Escaper, so helpers written against Escaper
+// can report into it, although, as with Off, they keep running after On
+// returns. The zero value is ready to use and Collect is safe for
+// concurrent use.
+type Collect struct {
+	mu      sync.Mutex
+	origins []origin
+}
+
+var _ Escaper = (*Collect)(nil)
+
+// Err returns the collected errors joined, or nil if none were collected.
+func (c *Collect) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return joinOrigins(c.origins)
+}
+
+// On adds each non-nil error in errs to the collection. Errors are kept in
+// the order they were added and Split reports where each was added.
+func (c *Collect) On(errs ...error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, err := range errs {
+		if err != nil {
+			c.origins = append(c.origins, originate(err))
+		}
+	}
+}
+
+// Flush triggers an escape with the collected errors joined, if any were
+// collected. The collection is emptied either way.
+func (c *Collect) Flush(escape Escaper) {
+	c.mu.Lock()
+	err := joinOrigins(c.origins)
+	c.origins = nil
+	c.mu.Unlock()
+
+	escape.On(err)
+}
+
+// Gatherer gathers errors from producer goroutines for the goroutine that
+// owns an escape, as in scatter/gather RPC patterns.
+type Gatherer struct {
+	c      Collect
+	escape Escaper
+}
+
+// Gather returns a Gatherer for escape. Producers report errors by calling
+// Report, which can be passed to them as a func(error), and the owner calls
+// Close once they are done:
+//
+//	g := handle.Gather(escape)
+//	for _, shard := range shards {
+//	    wg.Add(1)
+//	    go func(shard string) {
+//	        defer wg.Done()
+//	        g.Report(query(ctx, shard))
+//	    }(shard)
+//	}
+//	wg.Wait()
+//	g.Close()
+func Gather(escape Escaper) *Gatherer {
+	return &Gatherer{escape: escape}
+}
+
+// Report records err, if non-nil. It is safe to call from any goroutine
+// and never triggers an escape.
+func (g *Gatherer) Report(err error) {
+	g.c.On(err)
+}
+
+// Close triggers an escape with the reported errors joined, in the order
+// they were reported, if any were reported. It must be called by the
+// goroutine that owns the escape.
+func (g *Gatherer) Close() {
+	g.c.Flush(g.escape)
+}
@@ -0,0 +1,87 @@
+package handle_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleCollect() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "process")
+		defer hatch()
+
+		var c handle.Collect
+
+		for _, name := range names {
+			_, err = fails(name)
+			c.On(err)
+
+			fmt.Println("processed", name)
+		}
+
+		c.Flush(escape)
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b"))
+	// Output:
+	// processed a
+	// processed b
+	// process: failure
+	// failure
+}
+
+func ExampleSplit() {
+	var c handle.Collect
+
+	for _, name := range []string{"a", "b"} {
+		_, err := fails(name)
+		c.On(fmt.Errorf("%s: %w", name, err))
+	}
+
+	for _, o := range handle.Split(c.Err()) {
+		fmt.Println(o.Index, o.Err, strings.Contains(o.Site, "collect_test.go"))
+	}
+	// Output:
+	// 0 a: failure true
+	// 1 b: failure true
+}
+
+func ExampleGather() {
+	f := func(shards ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "query")
+		defer hatch()
+
+		g := handle.Gather(escape)
+
+		var wg sync.WaitGroup
+
+		for _, shard := range shards {
+			wg.Add(1)
+
+			go func(report func(error), shard string) {
+				defer wg.Done()
+
+				if shard == "" {
+					_, err := fails(shard)
+					report(err)
+				}
+			}(g.Report, shard)
+		}
+
+		wg.Wait()
+		g.Close()
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b"))
+	fmt.Println(f("a", ""))
+	// Output:
+	// <nil>
+	// query: failure
+}
@@ -0,0 +1,228 @@
+// Package config loads configuration from files and environment variables,
+// reporting failures through a handle.Escaper with the field at fault.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+var errUnsupported = errors.New("unsupported")
+
+// FieldError reports a problem with the value of a configuration field.
+type FieldError struct {
+	Source string
+	Field  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return e.Source + ": field " + e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Option configures Load.
+type Option func(*options)
+
+type options struct {
+	env    bool
+	prefix string
+}
+
+// Env overrides fields with environment variables named by prefix followed
+// by the field's env tag or, without a tag, the field name in upper snake
+// case. Nested structs add their own name and an underscore to the prefix.
+func Env(prefix string) Option {
+	return func(o *options) {
+		o.env = true
+		o.prefix = prefix
+	}
+}
+
+// Validator is implemented by configuration types that check their own
+// values once loaded.
+type Validator interface {
+	Validate() error
+}
+
+// Load decodes the JSON file at path, if path is not empty, into a T,
+// applies overrides from opts and validates the result. Failures trigger
+// escape with errors of the InvalidArgument kind.
+func Load[T any](escape handle.Escaper, path string, opts ...Option) T {
+	var (
+		cfg T
+		o   options
+	)
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if path != "" {
+		escape.On(handle.Tag(decode(path, &cfg), handle.InvalidArgument))
+	}
+
+	if o.env {
+		v := reflect.ValueOf(&cfg).Elem()
+		if v.Kind() == reflect.Struct {
+			escape.On(handle.Tag(env(v, o.prefix, ""), handle.InvalidArgument))
+		}
+	}
+
+	if v, ok := interface{}(&cfg).(Validator); ok {
+		escape.On(handle.Tag(v.Validate(), handle.InvalidArgument))
+	}
+
+	return cfg
+}
+
+func decode(path string, v interface{}) error {
+	if ext := filepath.Ext(path); ext != ".json" {
+		return fmt.Errorf("%s: %w format %q", path, errUnsupported, ext)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+
+	err = d.Decode(v)
+
+	var te *json.UnmarshalTypeError
+	if errors.As(err, &te) {
+		return &FieldError{Source: path, Field: te.Field, Err: err}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+func env(v reflect.Value, prefix, path string) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Tag.Get("env")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = snake(f.Name)
+		}
+
+		field := f.Name
+		if path != "" {
+			field = path + "." + f.Name
+		}
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}) {
+			if err := env(fv, prefix+name+"_", field); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		s, ok := os.LookupEnv(prefix + name)
+		if !ok {
+			continue
+		}
+
+		if err := set(fv, s); err != nil {
+			return &FieldError{Source: "env " + prefix + name, Field: field, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func set(v reflect.Value, s string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err == nil {
+			v.SetInt(int64(d))
+		}
+
+		return err
+	}
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("%w type %s", errUnsupported, v.Type())
+	}
+
+	return nil
+}
+
+// snake converts a Go identifier like MaxConns to MAX_CONNS.
+func snake(s string) string {
+	var b strings.Builder
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+	"github.com/michaelmacinnis/handle/config"
+)
+
+type Config struct {
+	Addr    string
+	MaxConn int
+	Timeout time.Duration
+	DB      struct {
+		Name string `env:"DATABASE"`
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.MaxConn <= 0 {
+		return errors.New("MaxConn must be positive")
+	}
+
+	return nil
+}
+
+func load(path string) (cfg Config, err error) {
+	escape, hatch := handle.Errorf(&err, "load config")
+	defer hatch()
+
+	return config.Load[Config](escape, path, config.Env("APP_")), nil
+}
+
+func Example() {
+	dir, _ := os.MkdirTemp("", "config")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.json")
+	_ = os.WriteFile(path, []byte(`{"Addr": ":8080", "MaxConn": 10}`), 0o600)
+
+	os.Setenv("APP_TIMEOUT", "5s")
+	os.Setenv("APP_DB_DATABASE", "users")
+
+	cfg, err := load(path)
+	fmt.Println(cfg.Addr, cfg.MaxConn, cfg.Timeout, cfg.DB.Name, err)
+
+	os.Setenv("APP_MAX_CONN", "many")
+
+	_, err = load(path)
+	fmt.Println(err)
+	fmt.Println(handle.KindOf(err))
+
+	os.Setenv("APP_MAX_CONN", "0")
+
+	_, err = load(path)
+	fmt.Println(err)
+	// Output:
+	// :8080 10 5s users <nil>
+	// load config: env APP_MAX_CONN: field MaxConn: strconv.ParseInt: parsing "many": invalid syntax
+	// invalid argument
+	// load config: MaxConn must be positive
+}
@@ -0,0 +1,62 @@
+package handle
+
+import "context"
+
+// WithCancel is like With but also returns a context derived from ctx that
+// is canceled, with the escaping error as its cause, as soon as an escape
+// is triggered. Calls sharing the context can then stop promptly while the
+// function unwinds. The hatch cancels the context when the function returns.
+func WithCancel(ctx context.Context, err *error, opts ...Option) (context.Context, *Escape, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	s, hatch := With(err, append(opts[:len(opts):len(opts)], func(s *Escape) {
+		s.onEscape = append(s.onEscape, cancel)
+		s.exit = append(s.exit, func() {
+			cancel(s.Err())
+		})
+	})...)
+
+	return ctx, s, hatch
+}
+
+// Cancel cancels a context, by calling cancel with the escaping error as
+// the cause, as soon as an escape is triggered on escape. This stops
+// in-flight sibling work sharing the context while the function unwinds:
+//
+//	ctx, cancel := context.WithCancelCause(ctx)
+//	defer cancel(nil)
+//	handle.Cancel(escape, cancel)
+func Cancel(escape *Escape, cancel context.CancelCauseFunc) {
+	escape.onEscape = append(escape.onEscape, cancel)
+}
+
+// OnCtx triggers an escape with context.Cause(ctx) if ctx is done. It
+// replaces checks of ctx.Err() scattered through long functions:
+//
+//	for _, item := range items {
+//	    escape.OnCtx(ctx)
+//	    // ...
+//	}
+func (s *Escape) OnCtx(ctx context.Context) {
+	if ctx.Err() != nil {
+		s.On(context.Cause(ctx))
+	}
+}
+
+type escapeKey struct{}
+
+// NewContext returns a context derived from ctx that carries escape so
+// that helpers deep in the same call chain can report errors to it
+// without the escape being threaded through every signature. As with any
+// escape, helpers must not call On from other goroutines, so the context
+// must not be passed to code that may use it concurrently.
+func NewContext(ctx context.Context, escape Escaper) context.Context {
+	return context.WithValue(ctx, escapeKey{}, escape)
+}
+
+// FromContext returns the escape carried by ctx, if any.
+func FromContext(ctx context.Context) (Escaper, bool) {
+	escape, ok := ctx.Value(escapeKey{}).(Escaper)
+
+	return escape, ok
+}
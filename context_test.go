@@ -0,0 +1,108 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleWithCancel() {
+	f := func() (err error) {
+		ctx, escape, hatch := handle.WithCancel(context.Background(), &err)
+		defer hatch()
+
+		defer handle.Chain(&err, func() {
+			fmt.Println(context.Cause(ctx))
+		})
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// failure
+	// failure
+}
+
+func ExampleEscape_OnCtx() {
+	f := func(ctx context.Context, items ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "process")
+		defer hatch()
+
+		for _, item := range items {
+			escape.OnCtx(ctx)
+
+			fmt.Println(item)
+		}
+
+		return nil
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errFailure)
+
+	fmt.Println(f(context.Background(), "a", "b"))
+	fmt.Println(f(ctx, "a", "b"))
+	// Output:
+	// a
+	// b
+	// <nil>
+	// process: failure
+}
+
+func ExampleCancel() {
+	f := func(ctx context.Context) (err error) {
+		escape, hatch := handle.Errorf(&err, "f")
+		defer hatch()
+
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		handle.Cancel(escape, cancel)
+
+		defer handle.Chain(&err, func() {
+			fmt.Println("sibling work stopped:", context.Cause(ctx))
+		})
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f(context.Background()))
+	// Output:
+	// sibling work stopped: failure
+	// f: failure
+}
+
+func ExampleFromContext() {
+	parse := func(ctx context.Context, s string) int {
+		escape, _ := handle.FromContext(ctx)
+
+		return handle.Check(strconv.Atoi(s))(escape)
+	}
+
+	f := func(ctx context.Context, s string) (err error) {
+		escape, hatch := handle.Errorf(&err, "f")
+		defer hatch()
+
+		ctx = handle.NewContext(ctx, escape)
+
+		fmt.Println(parse(ctx, s))
+
+		return nil
+	}
+
+	fmt.Println(f(context.Background(), "42"))
+	fmt.Println(f(context.Background(), "x"))
+	// Output:
+	// 42
+	// <nil>
+	// f: strconv.Atoi: parsing "x": invalid syntax
+}
@@ -0,0 +1,40 @@
+package handle
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DeprecationError reports the use of a deprecated code path.
+type DeprecationError struct {
+	What string
+	Site string
+}
+
+func (e *DeprecationError) Error() string {
+	return "deprecated: " + e.What
+}
+
+//nolint:gochecknoglobals
+var deprecated sync.Map
+
+// Deprecated reports that the caller used the deprecated code path what.
+// It never triggers an escape. The first use from each call site is logged
+// as a warning, with the call site, and every use is counted in the stats
+// registry so that remaining uses can be tracked alongside real errors:
+//
+//	escape.Deprecated("v1 endpoint used")
+func (s *Escape) Deprecated(what string) {
+	var pcs [callers]uintptr
+
+	runtime.Callers(2, pcs[:])
+
+	site := caller(pcs[:])
+	err := &DeprecationError{what, site}
+
+	stats.record("deprecated " + what + " at " + site)
+
+	if _, loaded := deprecated.LoadOrStore(site+" "+what, true); !loaded {
+		s.warn("deprecated", err, "site", site)
+	}
+}
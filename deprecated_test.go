@@ -0,0 +1,36 @@
+package handle_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_Deprecated() {
+	f := func(version int) (err error) {
+		escape, hatch := handle.With(&err, handle.Log(discard()))
+		defer hatch()
+
+		if version == 1 {
+			escape.Deprecated("v1 endpoint used")
+		}
+
+		return nil
+	}
+
+	before := handle.Stats()
+
+	for _, v := range []int{1, 2, 1} {
+		fmt.Println(f(v))
+	}
+
+	for _, d := range handle.DiffStats(before, handle.Stats()) {
+		fmt.Println(strings.HasPrefix(d.Fingerprint, "deprecated v1 endpoint used at "), d.After)
+	}
+	// Output:
+	// <nil>
+	// <nil>
+	// <nil>
+	// true 2
+}
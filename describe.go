@@ -0,0 +1,110 @@
+package handle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"time"
+)
+
+// Description reports how an Escape is configured. It is meant for
+// inspecting, at runtime, why an error was or wasn't wrapped, logged or
+// reported as expected.
+type Description struct {
+	Created        string        `json:"created,omitempty"`
+	Op             string        `json:"op,omitempty"`
+	Annotations    []string      `json:"annotations,omitempty"`
+	Handlers       []string      `json:"handlers,omitempty"`
+	Finalizers     []string      `json:"finalizers,omitempty"`
+	Cleanups       []string      `json:"cleanups,omitempty"`
+	Checkpoint     string        `json:"checkpoint,omitempty"`
+	Collision      string        `json:"collision"`
+	HandlerTimeout time.Duration `json:"handlerTimeout,omitempty"`
+	Levels         int           `json:"levels,omitempty"`
+	Logger         bool          `json:"logger"`
+	CatchPanics    bool          `json:"catchPanics,omitempty"`
+	Dedupe         bool          `json:"dedupe,omitempty"`
+	Grace          bool          `json:"grace,omitempty"`
+	JoinWarnings   bool          `json:"joinWarnings,omitempty"`
+	Timed          bool          `json:"timed,omitempty"`
+	Escaped        bool          `json:"escaped"`
+	Err            string        `json:"err,omitempty"`
+}
+
+// Describe reports the options, handlers, cleanups and annotations
+// registered on escape. Handlers are listed in the order the hatch calls
+// them and are named by the function that created them, such as
+// handle.Wrapf, or the function literal passed to Error. Created is only
+// reported for escapes created with the CheckGoroutine option.
+func Describe(escape *Escape) Description {
+	s := escape
+
+	var cleanups []string
+
+	if mu := s.locked(); mu != nil {
+		for _, c := range s.cleanups {
+			cleanups = append(cleanups, c.group)
+		}
+		mu.Unlock()
+	}
+
+	d := Description{
+		Created:        caller(s.created[:]),
+		Op:             s.op,
+		Cleanups:       cleanups,
+		Checkpoint:     s.checkpoint,
+		Collision:      s.collision.String(),
+		HandlerTimeout: s.handlerTimeout,
+		Levels:         len(s.levels),
+		Logger:         s.logger != nil,
+		CatchPanics:    s.catch,
+		Dedupe:         s.dedupe,
+		Grace:          s.grace,
+		JoinWarnings:   s.joinWarnings,
+		Timed:          s.timed,
+		Escaped:        s.escaped,
+	}
+
+	for _, a := range s.annotations {
+		d.Annotations = append(d.Annotations, fmt.Sprintf(a.format, a.args...))
+	}
+
+	for i := len(s.fns) - 1; i >= 0; i-- {
+		d.Handlers = append(d.Handlers, funcName(s.fns[i]))
+	}
+
+	for _, fn := range s.final {
+		d.Finalizers = append(d.Finalizers, funcName(fn))
+	}
+
+	if err := s.Err(); err != nil {
+		d.Err = err.Error()
+	}
+
+	return d
+}
+
+// ServeDefaults is an http.HandlerFunc that writes, as JSON, the
+// Description of an escape created with only the options set by
+// SetDefaults. The escape's hatch runs before ServeDefaults returns so
+// that options such as Region are closed.
+func ServeDefaults(w http.ResponseWriter, _ *http.Request) {
+	var err error
+
+	s, hatch := With(&err)
+	defer hatch()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(Describe(s))
+}
+
+func funcName(fn interface{}) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+		return f.Name()
+	}
+
+	return "unknown"
+}
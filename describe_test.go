@@ -0,0 +1,45 @@
+package handle_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleDescribe() {
+	var err error
+
+	escape, hatch := handle.With(&err,
+		handle.Op("copy"),
+		handle.Wrapf("copy %s %s", "src", "dst"),
+		handle.OnCollision(handle.JoinErrors),
+	)
+	defer hatch()
+
+	escape.Cleanup("files", func() {})
+
+	d := handle.Describe(escape)
+	fmt.Println(d.Op, d.Annotations, d.Cleanups, d.Collision)
+
+	for _, h := range d.Handlers {
+		fmt.Println(strings.TrimPrefix(h, "github.com/michaelmacinnis/"))
+	}
+	// Output:
+	// copy [copy src dst] [files] join errors
+	// handle.Wrapf.func1.1
+}
+
+func ExampleServeDefaults() {
+	handle.SetDefaults(handle.Op("service"), handle.Wrapf("service"))
+	defer handle.SetDefaults()
+
+	w := httptest.NewRecorder()
+	handle.ServeDefaults(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	fmt.Print(w.Body.String())
+	// Output:
+	// {"op":"service","annotations":["service"],"handlers":["github.com/michaelmacinnis/handle.Wrapf.func1.1"],"collision":"keep last","logger":false,"escaped":false}
+}
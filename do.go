@@ -0,0 +1,29 @@
+package handle
+
+// Do calls fn with an escape configured by opts and returns the resulting
+// error. The hatch is deferred by Do so it cannot be forgotten:
+//
+//	err := handle.Do(func(escape *handle.Escape) error {
+//	    f, err := os.Open(name)
+//	    escape.On(err)
+//
+//	    defer f.Close()
+//
+//	    // ...
+//
+//	    return nil
+//	}, handle.Wrapf("read %s", name))
+func Do(fn func(escape *Escape) error, opts ...Option) (err error) {
+	escape, hatch := With(&err, opts...)
+	defer hatch()
+
+	return fn(escape)
+}
+
+// Do1 is like Do for functions that also return a value.
+func Do1[T any](fn func(escape *Escape) (T, error), opts ...Option) (v T, err error) {
+	escape, hatch := With(&err, opts...)
+	defer hatch()
+
+	return fn(escape)
+}
@@ -0,0 +1,41 @@
+package handle_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleDo() {
+	err := handle.Do(func(escape *handle.Escape) error {
+		s, err := works("World!")
+		escape.On(err)
+
+		fmt.Println(s)
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}, handle.Wrapf("do"))
+
+	fmt.Println(err)
+	// Output:
+	// Hello, World!
+	// do: failure
+}
+
+func ExampleDo1() {
+	parse := func(s string) (int, error) {
+		return handle.Do1(func(escape *handle.Escape) (int, error) {
+			return handle.Check(strconv.Atoi(s))(escape), nil
+		}, handle.Wrapf("parse(%q)", s))
+	}
+
+	fmt.Println(parse("42"))
+	fmt.Println(parse("x"))
+	// Output:
+	// 42 <nil>
+	// 0 parse("x"): strconv.Atoi: parsing "x": invalid syntax
+}
@@ -0,0 +1,130 @@
+package handle
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// encodingVersion is the version of the encoding written by Encode.
+const encodingVersion = 1
+
+// RemoteError is an error decoded by Decode. It carries the message, Kind
+// and chain of the original error but not its types.
+type RemoteError struct {
+	Version int
+	Message string
+
+	// RawKind is the kind as encoded. It differs from the value returned
+	// by Kind when the kind is not registered in this process.
+	RawKind string
+
+	// Chain holds the message of each error in the original chain,
+	// outermost first.
+	Chain []string
+
+	// Unknown holds fields written by other versions of this package so
+	// that they survive being decoded and encoded again.
+	Unknown map[string]json.RawMessage
+
+	kind Kind
+}
+
+func (e *RemoteError) Error() string {
+	return e.Message
+}
+
+func (e *RemoteError) Is(target error) bool {
+	if k, ok := target.(Kind); ok {
+		return e.kind != "" && k == e.kind
+	}
+
+	return e.kind != "" && registry.equivalent(e.kind, target)
+}
+
+// Kind returns the kind of the original error or Internal if that kind is
+// not registered in this process.
+func (e *RemoteError) Kind() Kind {
+	return e.kind
+}
+
+// Encode returns a JSON encoding of err, its Kind and the messages of the
+// errors in its chain, for exchange with other processes. Decode accepts
+// encodings written by both older and newer versions of this package.
+// Encode returns nil, and no error, if err is nil.
+func Encode(err error) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+
+	fields := map[string]interface{}{}
+
+	var remote *RemoteError
+	if errors.As(err, &remote) && remote.Message == err.Error() {
+		for k, v := range remote.Unknown {
+			fields[k] = v
+		}
+
+		fields["kind"] = remote.RawKind
+		fields["chain"] = remote.Chain
+	} else {
+		fields["kind"] = string(KindOf(err))
+		fields["chain"] = chain(err)
+	}
+
+	fields["version"] = encodingVersion
+	fields["message"] = err.Error()
+
+	return json.Marshal(fields)
+}
+
+// Decode returns the error encoded in data by Encode. Fields it does not
+// recognize are preserved in Unknown and a kind that is not registered is
+// mapped to Internal.
+func Decode(data []byte) (*RemoteError, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	e := &RemoteError{}
+
+	for name, dst := range map[string]interface{}{
+		"version": &e.Version,
+		"message": &e.Message,
+		"kind":    &e.RawKind,
+		"chain":   &e.Chain,
+	} {
+		if raw, ok := fields[name]; ok {
+			if err := json.Unmarshal(raw, dst); err != nil {
+				return nil, err
+			}
+
+			delete(fields, name)
+		}
+	}
+
+	if len(fields) > 0 {
+		e.Unknown = fields
+	}
+
+	if e.RawKind != "" {
+		e.kind = Kind(e.RawKind)
+		if _, ok := registry.Lookup(e.kind); !ok {
+			e.kind = Internal
+		}
+	}
+
+	return e, nil
+}
+
+// chain returns the messages of the errors in err's chain, outermost
+// first. Joined errors end the chain.
+func chain(err error) []string {
+	var msgs []string
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		msgs = append(msgs, err.Error())
+	}
+
+	return msgs
+}
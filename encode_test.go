@@ -0,0 +1,36 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleDecode() {
+	err := fmt.Errorf("lookup: %w", handle.Tag(errors.New("no such user"), handle.NotFound))
+
+	data, _ := handle.Encode(err)
+
+	remote, _ := handle.Decode(data)
+	fmt.Println(remote)
+	fmt.Println(errors.Is(remote, handle.NotFound))
+
+	// An encoding from a newer version with an unknown kind and field.
+	remote, _ = handle.Decode([]byte(`{"version":2,"message":"slow down","kind":"throttled","retry":3}`))
+	fmt.Println(remote, remote.Kind(), remote.RawKind)
+
+	data, _ = handle.Encode(remote)
+	fmt.Println(string(data))
+	// Output:
+	// lookup: no such user
+	// true
+	// slow down internal throttled
+	// {"chain":null,"kind":"throttled","message":"slow down","retry":3,"version":1}
+}
+
+func ExampleEncode() {
+	data, err := handle.Encode(nil)
+	fmt.Println(data == nil, err)
+	// Output: true <nil>
+}
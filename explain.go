@@ -0,0 +1,54 @@
+package handle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Explain writes a human-oriented explanation of err to w: the error,
+// the step that failed, if it was recorded by Checkpoint, and the
+// description and suggested remediation registered for its Kind. It is
+// meant for command line programs, where a block like
+//
+//	error: copy src dst: while parsing header: unexpected EOF
+//	  step: parsing header
+//	  kind: internal (INTERNAL)
+//	  what: An unexpected condition was encountered.
+//	  fix:  Retry later. If the problem persists, report it.
+//
+// is more helpful to users than a single wrapped line.
+func Explain(w io.Writer, err error) {
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "error: %v\n", err)
+
+	var ce *CheckpointError
+	if errors.As(err, &ce) {
+		fmt.Fprintf(w, "  step: %s\n", ce.Label)
+	}
+
+	k := KindOf(err)
+	if k == "" {
+		return
+	}
+
+	info, ok := registry.Lookup(k)
+	if !ok {
+		fmt.Fprintf(w, "  kind: %s\n", k)
+
+		return
+	}
+
+	fmt.Fprintf(w, "  kind: %s (%s)\n", k, info.Code)
+
+	if info.Description != "" {
+		fmt.Fprintf(w, "  what: %s\n", info.Description)
+	}
+
+	if info.Remediation != "" {
+		fmt.Fprintf(w, "  fix:  %s\n", info.Remediation)
+	}
+}
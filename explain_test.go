@@ -0,0 +1,28 @@
+package handle_test
+
+import (
+	"io"
+	"os"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleExplain() {
+	f := func(src, dst string) (err error) {
+		escape, hatch := handle.Errorf(&err, "copy %s %s", src, dst)
+		defer hatch()
+
+		escape.Checkpoint("parsing header")
+		escape.On(handle.Tag(io.ErrUnexpectedEOF, handle.Internal))
+
+		return nil
+	}
+
+	handle.Explain(os.Stdout, f("src", "dst"))
+	// Output:
+	// error: copy src dst: while parsing header: unexpected EOF
+	//   step: parsing header
+	//   kind: internal (INTERNAL)
+	//   what: An unexpected condition was encountered.
+	//   fix:  Retry later. If the problem persists, report it.
+}
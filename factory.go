@@ -0,0 +1,40 @@
+package handle
+
+// Factory creates escapes with a common set of options. It complements
+// SetDefaults by allowing per-package or per-component configuration,
+// such as a different logger, without global mutation:
+//
+//	var h = handle.NewFactory(handle.Log(logger))
+//
+//	func do() (err error) {
+//	    escape, hatch := h.Errorf(&err, "do")
+//	    defer hatch()
+//
+//	    // ...
+//	}
+//
+// The name New is taken by the constructor for Handle.
+type Factory struct {
+	opts []Option
+}
+
+// NewFactory returns a Factory that applies opts.
+func NewFactory(opts ...Option) *Factory {
+	return &Factory{opts: append([]Option{}, opts...)}
+}
+
+// Error is like the package level Error with the factory's options.
+func (f *Factory) Error(err *error, fns ...func()) (*Escape, func()) {
+	return f.With(err, Handlers(fns...))
+}
+
+// Errorf is like the package level Errorf with the factory's options.
+func (f *Factory) Errorf(err *error, format string, args ...interface{}) (*Escape, func()) {
+	return f.With(err, Wrapf(format, args...))
+}
+
+// With is like the package level With with the factory's options applied
+// after any defaults and before opts.
+func (f *Factory) With(err *error, opts ...Option) (*Escape, func()) {
+	return With(err, append(f.opts[:len(f.opts):len(f.opts)], opts...)...)
+}
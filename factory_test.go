@@ -0,0 +1,30 @@
+package handle_test
+
+import (
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleFactory() {
+	h := handle.NewFactory(handle.Log(logger()), handle.MaxLen(16))
+
+	f := func() (err error) {
+		escape, hatch := h.Errorf(&err, "a long annotation")
+		defer hatch()
+
+		escape.Best(func() error {
+			return errFailure
+		}, "warm cache")
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// level=WARN msg="warm cache" error=failure
+	// a long annotatio... (10 bytes truncated)
+}
@@ -0,0 +1,155 @@
+// Package flagx validates command lines parsed with the flag package,
+// reporting problems through a handle.Escaper as usage errors.
+package flagx
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+// UsageError reports a command line that is not valid.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+func usage(format string, args ...interface{}) error {
+	return &UsageError{fmt.Errorf(format, args...)} //nolint:goerr113
+}
+
+// Parse parses args with fs. Parse errors, including a request for help,
+// trigger escape with a *UsageError. The flag set's own error and usage
+// output is suppressed so that Report can print it once.
+func Parse(escape handle.Escaper, fs *flag.FlagSet, args []string) {
+	out := fs.Output()
+	fs.SetOutput(io.Discard)
+
+	err := fs.Parse(args)
+
+	fs.SetOutput(out)
+
+	if err != nil {
+		escape.On(&UsageError{err})
+	}
+}
+
+// Required triggers escape if any of the flags named are not set on the
+// command line.
+func Required(escape handle.Escaper, fs *flag.FlagSet, names ...string) {
+	set := visited(fs)
+
+	var missing []string
+
+	for _, name := range names {
+		if !set[name] {
+			missing = append(missing, "-"+name)
+		}
+	}
+
+	if len(missing) > 0 {
+		escape.On(usage("missing required flags: %s", strings.Join(missing, ", ")))
+	}
+}
+
+// Exclusive triggers escape if more than one of the flags named is set on
+// the command line.
+func Exclusive(escape handle.Escaper, fs *flag.FlagSet, names ...string) {
+	set := visited(fs)
+
+	var both []string
+
+	for _, name := range names {
+		if set[name] {
+			both = append(both, "-"+name)
+		}
+	}
+
+	if len(both) > 1 {
+		escape.On(usage("flags are mutually exclusive: %s", strings.Join(both, ", ")))
+	}
+}
+
+// Args triggers escape if the number of arguments remaining after the
+// flags is less than least or, when most is not negative, more than most.
+func Args(escape handle.Escaper, fs *flag.FlagSet, least, most int) {
+	n := fs.NArg()
+
+	switch {
+	case n < least:
+		escape.On(usage("expected at least %d arguments, got %d", least, n))
+	case most >= 0 && n > most:
+		escape.On(usage("expected at most %d arguments, got %d", most, n))
+	}
+}
+
+// Code returns the exit code for err: 0 for nil or a request for help, 2
+// for a usage error and 1 for any other error.
+func Code(err error) int {
+	var ue *UsageError
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &ue):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// ExplainErrors defines the -explain-errors flag on fs. When it is set,
+// Report explains errors other than usage errors with handle.Explain
+// instead of printing them on a single line.
+func ExplainErrors(fs *flag.FlagSet) {
+	fs.Bool(explainFlag, false, "explain errors instead of printing a single line")
+}
+
+const explainFlag = "explain-errors"
+
+// Report prints err to the output of fs, followed by the usage message for
+// usage errors, and returns the exit code for err.
+func Report(fs *flag.FlagSet, err error) int {
+	code := Code(err)
+
+	switch {
+	case err == nil || errors.Is(err, flag.ErrHelp):
+	case code != 2 && explain(fs):
+		handle.Explain(fs.Output(), err)
+	default:
+		fmt.Fprintf(fs.Output(), "%s: %s\n", fs.Name(), err)
+	}
+
+	if errors.Is(err, flag.ErrHelp) || code == 2 {
+		fs.Usage()
+	}
+
+	return code
+}
+
+func explain(fs *flag.FlagSet) bool {
+	f := fs.Lookup(explainFlag)
+
+	return f != nil && f.Value.String() == "true"
+}
+
+func visited(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	return set
+}
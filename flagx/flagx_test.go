@@ -0,0 +1,74 @@
+package flagx_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/michaelmacinnis/handle"
+	"github.com/michaelmacinnis/handle/flagx"
+)
+
+func run(args []string) int {
+	fs := flag.NewFlagSet("copy", flag.ContinueOnError)
+	fs.SetOutput(os.Stdout)
+
+	fs.Bool("force", false, "overwrite dst")
+	fs.Bool("no-clobber", false, "never overwrite dst")
+
+	err := func() (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		flagx.Parse(escape, fs, args)
+		flagx.Exclusive(escape, fs, "force", "no-clobber")
+		flagx.Args(escape, fs, 2, 2)
+
+		fmt.Println("copy", fs.Arg(0), fs.Arg(1))
+
+		return nil
+	}()
+
+	return flagx.Report(fs, err)
+}
+
+func Example() {
+	fmt.Println(run([]string{"-force", "src", "dst"}))
+	fmt.Println(run([]string{"-force", "-no-clobber", "src", "dst"}))
+	// Output:
+	// copy src dst
+	// 0
+	// copy: flags are mutually exclusive: -force, -no-clobber
+	// Usage of copy:
+	//   -force
+	//     	overwrite dst
+	//   -no-clobber
+	//     	never overwrite dst
+	// 2
+}
+
+func ExampleExplainErrors() {
+	fs := flag.NewFlagSet("find", flag.ContinueOnError)
+	fs.SetOutput(os.Stdout)
+
+	flagx.ExplainErrors(fs)
+
+	err := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "find")
+		defer hatch()
+
+		flagx.Parse(escape, fs, []string{"-explain-errors", "ann"})
+
+		escape.On(handle.Tag(fmt.Errorf("no user %q", fs.Arg(0)), handle.NotFound))
+
+		return nil
+	}()
+
+	fmt.Println(flagx.Report(fs, err))
+	// Output:
+	// error: find: no user "ann"
+	//   kind: not found (NOT_FOUND)
+	//   what: The requested entity was not found.
+	//   fix:  Check that the name or ID is correct and that it exists.
+	// 1
+}
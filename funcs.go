@@ -0,0 +1,43 @@
+package handle
+
+// Funcs is a lightweight alternative to Error that returns plain functions
+// in place of the escape object. Passing on around does not allocate a
+// method value and the state shared by on and hatch is limited to the
+// bound error and a flag. Funcs supports only handler functions; use Error
+// or With for anything else.
+func Funcs(err *error, fns ...func()) (on func(error), hatch func()) {
+	var (
+		pnc    bool
+		shared error
+	)
+
+	if err == nil {
+		err = &shared
+	}
+
+	on = func(ce error) {
+		if ce != nil {
+			*err = ce
+
+			if !pnc {
+				pnc = true
+
+				panic(failure{error: ce})
+			}
+		}
+	}
+
+	hatch = func() {
+		if pnc {
+			pnc = false
+
+			_ = recover()
+		}
+
+		for i := len(fns) - 1; *err != nil && i >= 0; i-- {
+			fns[i]()
+		}
+	}
+
+	return on, hatch
+}
@@ -0,0 +1,27 @@
+package handle_test
+
+import (
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleFuncs() {
+	f := func() (err error) {
+		on, hatch := handle.Funcs(&err, func() {
+			err = fmt.Errorf("f: %w", err)
+		})
+		defer hatch()
+
+		_, err = works("World!")
+		on(err)
+
+		_, err = fails("World!")
+		on(err)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: f: failure
+}
@@ -0,0 +1,67 @@
+package handle
+
+import "sync/atomic"
+
+// Flags is a feature flag provider. Enabled reports whether flag is on for
+// the operation op, as named by the Op option, which may be empty.
+type Flags interface {
+	Enabled(op, flag string) bool
+}
+
+// FlagsFunc adapts a function to the Flags interface.
+type FlagsFunc func(op, flag string) bool
+
+// Enabled calls f(op, flag).
+func (f FlagsFunc) Enabled(op, flag string) bool {
+	return f(op, flag)
+}
+
+//nolint:gochecknoglobals
+var flags atomic.Pointer[Flags]
+
+// SetFlags sets the feature flag provider consulted by Gate. Because the
+// provider is consulted each time an escape is created, the behavior of
+// escapes can be switched at run time, per operation, without a redeploy.
+func SetFlags(f Flags) {
+	flags.Store(&f)
+}
+
+// Gate applies opts only if flag is enabled, by the provider set with
+// SetFlags, for the escape's operation. Gates are evaluated after all other
+// options, including defaults, so the operation is known even if Op is
+// passed after Gate. This enables gradual rollout of stricter error
+// handling, such as more verbose wrapping or new report destinations:
+//
+//	handle.SetDefaults(handle.Gate("strict-errors", handle.CatchPanics(), handle.JoinWarnings()))
+func Gate(flag string, opts ...Option) Option {
+	return func(s *Escape) {
+		s.gates = append(s.gates, func() {
+			if s.Gate(flag) {
+				for _, opt := range opts {
+					opt(s)
+				}
+			}
+		})
+	}
+}
+
+// Gate reports whether flag is enabled for the escape's operation by the
+// provider set with SetFlags. It lets code inside a function switch
+// behavior on the same flags as the Gate option.
+func (s *Escape) Gate(flag string) bool {
+	f := flags.Load()
+
+	return f != nil && *f != nil && (*f).Enabled(s.op, flag)
+}
+
+func (s *Escape) applyGates() {
+	// Options applied by a gate may add gates of their own.
+	for len(s.gates) > 0 {
+		gates := s.gates
+		s.gates = nil
+
+		for _, gate := range gates {
+			gate()
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package handle_test
+
+import (
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleGate() {
+	handle.SetFlags(handle.FlagsFunc(func(op, flag string) bool {
+		return op == "billing.charge" && flag == "verbose-errors"
+	}))
+	defer handle.SetFlags(nil)
+
+	f := func(op string) (err error) {
+		escape, hatch := handle.With(&err,
+			handle.Gate("verbose-errors", handle.Wrapf("%s", op)),
+			handle.Op(op),
+		)
+		defer hatch()
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f("billing.charge"))
+	fmt.Println(f("billing.refund"))
+	// Output:
+	// billing.charge: failure
+	// failure
+}
@@ -0,0 +1,56 @@
+package handle
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// CheckGoroutine records the goroutine that creates the escape and makes
+// On panic, with a clear message, when called from any other goroutine.
+// Triggering an escape from another goroutine would otherwise crash the
+// program with a confusing panic or silently corrupt control flow. It also
+// records where the escape was created so that this message, and the one
+// for an escape that is never handled, can name it. Finding the current
+// goroutine and the creation site is not cheap, so this is intended for
+// debug builds and tests:
+//
+//	if debug {
+//	    handle.SetDefaults(handle.CheckGoroutine())
+//	}
+func CheckGoroutine() Option {
+	return func(s *Escape) {
+		s.goroutine = goid()
+		runtime.Callers(2, s.created[:])
+	}
+}
+
+func (s *Escape) checkGoroutine() {
+	if s.goroutine == 0 {
+		return
+	}
+
+	if id := goid(); id != s.goroutine {
+		panic(fmt.Sprintf(
+			"handle: escape.On called from a different goroutine (goroutine %d) than the one that created the escape (goroutine %d) at %s",
+			id, s.goroutine, caller(s.created[:]),
+		))
+	}
+}
+
+// goid returns the ID of the current goroutine.
+func goid() uint64 {
+	var buf [64]byte
+
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+
+	return id
+}
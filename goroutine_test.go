@@ -0,0 +1,36 @@
+package handle_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleCheckGoroutine() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err, handle.CheckGoroutine())
+		defer hatch()
+
+		done := make(chan string)
+
+		go func() {
+			defer func() {
+				msg, _ := recover().(string)
+				done <- msg
+			}()
+
+			escape.On(errFailure)
+		}()
+
+		msg := <-done
+		fmt.Println(strings.HasPrefix(msg, "handle: escape.On called from a different goroutine"))
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// true
+	// <nil>
+}
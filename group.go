@@ -0,0 +1,176 @@
+package handle
+
+import (
+	"context"
+	"sync"
+)
+
+// Group runs functions in goroutines, each with its own escape and hatch,
+// so that code running concurrently can keep the flat escape.On style. An
+// escape must not be shared across goroutines. A zero Group is ready to use
+// but has no context to cancel.
+type Group struct {
+	cancel context.CancelCauseFunc
+	errs   []error
+	first  error
+	mu     sync.Mutex
+	opts   []Option
+	wg     sync.WaitGroup
+}
+
+// NewGroup returns a Group and a context derived from ctx that is canceled,
+// with the error as its cause, when a function started by Go fails. Each
+// function's escape is created with opts.
+func NewGroup(ctx context.Context, opts ...Option) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	return &Group{cancel: cancel, opts: opts}, ctx
+}
+
+// Go calls fn in a new goroutine with an escape whose hatch is deferred
+// for it:
+//
+//	g.Go(func(escape *handle.Escape) {
+//	    escape.On(fetch(ctx, url))
+//	})
+func (g *Group) Go(fn func(escape *Escape)) {
+	g.mu.Lock()
+	i := len(g.errs)
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		if err := call(fn, g.opts...); err != nil {
+			g.mu.Lock()
+			g.errs[i] = err
+			if g.first == nil {
+				g.first = err
+			}
+			g.mu.Unlock()
+
+			if g.cancel != nil {
+				g.cancel(err)
+			}
+		}
+	}()
+}
+
+// Wait waits for all functions started by Go to return and then triggers
+// an escape with the first error, in time, if any failed.
+func (g *Group) Wait(escape Escaper) {
+	g.wait()
+
+	escape.On(g.first)
+}
+
+// WaitAll waits for all functions started by Go to return and then
+// triggers an escape with the errors of those that failed joined in the
+// order the functions were started.
+func (g *Group) WaitAll(escape Escaper) {
+	g.wait()
+
+	escape.On(join(g.errs))
+}
+
+func (g *Group) wait() {
+	g.wg.Wait()
+
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+}
+
+// WaitGroup is a sync.WaitGroup that records the first error returned by
+// the functions it runs. It suits the common case of starting a few
+// goroutines and failing if any failed, where a Group is more than needed.
+// A zero WaitGroup is ready to use.
+type WaitGroup struct {
+	first error
+	mu    sync.Mutex
+	wg    sync.WaitGroup
+}
+
+// Go calls fn in a new goroutine, recording its error if it is the first,
+// in time, to fail.
+func (w *WaitGroup) Go(fn func() error) {
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+
+		if err := fn(); err != nil {
+			w.mu.Lock()
+			if w.first == nil {
+				w.first = err
+			}
+			w.mu.Unlock()
+		}
+	}()
+}
+
+// Wait waits for all functions started by Go to return and then triggers
+// an escape with the first error, in time, if any failed.
+func (w *WaitGroup) Wait(escape Escaper) {
+	w.wg.Wait()
+
+	escape.On(w.first)
+}
+
+// Go calls fn in a new goroutine with its own escape and hatch. Wait
+// propagates the first failure into s. The hatch of s waits for any
+// goroutines still running so that they never outlive the function and,
+// if the function is otherwise succeeding, returns the first failure of
+// those that Wait did not already propagate:
+//
+//	escape.Go(func(escape *handle.Escape) {
+//	    escape.On(warm(ctx))
+//	})
+//	// ...
+//	escape.Wait()
+//
+// The goroutines are not canceled when s escapes, so the hatch waits for
+// them to finish. Functions that should stop early can use a context that
+// the escape cancels, with Cancel, or a Group created by NewGroup.
+func (s *Escape) Go(fn func(escape *Escape)) {
+	mu := s.lock()
+	if s.group == nil {
+		s.group = &Group{}
+	}
+	g := s.group
+	mu.Unlock()
+
+	g.Go(fn)
+}
+
+// Wait waits for the goroutines started by Go and triggers an escape with
+// the first error, in time, if any failed.
+func (s *Escape) Wait() {
+	if g := s.children(); g != nil {
+		g.Wait(s)
+	}
+}
+
+func (s *Escape) waitChildren() {
+	if g := s.children(); g != nil {
+		g.wait()
+
+		if *s.err == nil {
+			s.set(g.first)
+		}
+	}
+}
+
+func (s *Escape) children() *Group {
+	mu := s.locked()
+	if mu == nil {
+		return nil
+	}
+
+	defer mu.Unlock()
+
+	return s.group
+}
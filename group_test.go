@@ -0,0 +1,130 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleGroup() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "greet all")
+		defer hatch()
+
+		g, _ := handle.NewGroup(context.Background())
+
+		greetings := make([]string, len(names))
+		for i, name := range names {
+			i, name := i, name
+
+			g.Go(func(escape *handle.Escape) {
+				greet := works
+				if name == "" {
+					greet = fails
+				}
+
+				s, err := greet(name)
+				escape.On(err)
+
+				greetings[i] = s
+			})
+		}
+
+		g.WaitAll(escape)
+
+		fmt.Println(greetings)
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b"))
+	fmt.Println(f("a", "", "b"))
+	// Output:
+	// [Hello, a Hello, b]
+	// <nil>
+	// greet all: failure
+}
+
+func ExampleEscape_Go() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "warm")
+		defer hatch()
+
+		for _, name := range names {
+			name := name
+
+			escape.Go(func(escape *handle.Escape) {
+				greet := works
+				if name == "" {
+					greet = fails
+				}
+
+				_, err := greet(name)
+				escape.On(err)
+			})
+		}
+
+		escape.Wait()
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b"))
+	fmt.Println(f("a", ""))
+	// Output:
+	// <nil>
+	// warm: failure
+}
+
+func ExampleEscape_Go_withoutWait() {
+	f := func(name string) (err error) {
+		escape, hatch := handle.Errorf(&err, "warm")
+		defer hatch()
+
+		escape.Go(func(escape *handle.Escape) {
+			_, err := fails(name)
+			escape.On(err)
+		})
+
+		// The hatch waits for the goroutine and returns its failure.
+		return nil
+	}
+
+	fmt.Println(f("a"))
+	// Output: warm: failure
+}
+
+func ExampleWaitGroup() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "greet all")
+		defer hatch()
+
+		var wg handle.WaitGroup
+
+		for _, name := range names {
+			name := name
+
+			wg.Go(func() error {
+				greet := works
+				if name == "" {
+					greet = fails
+				}
+
+				_, err := greet(name)
+
+				return err
+			})
+		}
+
+		wg.Wait(escape)
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b"))
+	fmt.Println(f("a", "", "b"))
+	// Output:
+	// <nil>
+	// greet all: failure
+}
@@ -6,10 +6,10 @@
 // few errors need to be handled, it is best to handle errors with a simple
 // if statement:
 //
-//     f, err := os.Open(name)
-//     if err != nil {
-//         // Handle error.
-//     }
+//	f, err := os.Open(name)
+//	if err != nil {
+//	    // Handle error.
+//	}
 //
 // And, of course, the usual advice about treating errors as values and using
 // the full power of Go to simplify error handling applies.
@@ -25,90 +25,98 @@
 //
 // The error returned can be wrapped:
 //
-//     func do(name string) (err error) {
-//         escape, hatch := handle.Errorf(&err, "do(%s)", name)
-//         defer hatch()
+//	func do(name string) (err error) {
+//	    escape, hatch := handle.Errorf(&err, "do(%s)", name)
+//	    defer hatch()
 //
-//         // ...
+//	    // ...
 //
-//         return
-//     }
+//	    return
+//	}
 //
 // or returned unmodified:
 //
-//     func do(name string) (err error) {
-//         escape, hatch := handle.Error(&err)
-//         defer hatch()
+//	func do(name string) (err error) {
+//	    escape, hatch := handle.Error(&err)
+//	    defer hatch()
 //
-//         // ...
+//	    // ...
 //
-//         return
-//     }
+//	    return
+//	}
 //
 // With a deferred hatch, any call to escape.On with a non-nil error will
 // cause the enclosing function to return:
 //
-//     // Return if err is not nil.
-//     f, err := os.Open(name)
-//     escape.On(err)
+//	// Return if err is not nil.
+//	f, err := os.Open(name)
+//	escape.On(err)
 //
 // An enclosing function can use escape.On to trigger an early return with
 // shared behavior on errors:
 //
-//     func do(name string) (err error) {
-//         escape, hatch := handle.Error(&err, func(){
-//             // Log err.
-//         })
-//         defer hatch()
+//	func do(name string) (err error) {
+//	    escape, hatch := handle.Error(&err, func(){
+//	        // Log err.
+//	    })
+//	    defer hatch()
 //
-//         //...
+//	    //...
 //
-//         return
-//     }
+//	    return
+//	}
 //
 // and it can do so even if the enclosing function does not return an error:
 //
-//     func do(name string) {
-//         var err error
-//         escape, hatch := handle.Error(&err, func(){
-//             // Log err.
-//         })
-//         defer hatch()
+//	func do(name string) {
+//	    var err error
+//	    escape, hatch := handle.Error(&err, func(){
+//	        // Log err.
+//	    })
+//	    defer hatch()
 //
-//         //...
+//	    //...
 //
-//         return
-//     }
+//	    return
+//	}
 //
 // Additional error handling actions can be added with handle.Chain as in
 // the example below adapted from Error Handling - Problem Overview:
 //
 // github.com/golang/proposal/blob/master/design/go2draft-error-handling-overview.md
 //
-//     func CopyFile(src, dst string) (err error) {
-//         escape, hatch := handle.Errorf(&err, "copy %s %s", src, dst)
-//         defer hatch()
+//	func CopyFile(src, dst string) (err error) {
+//	    escape, hatch := handle.Errorf(&err, "copy %s %s", src, dst)
+//	    defer hatch()
 //
-//         r, err := os.Open(src)
-//         escape.On(err)
+//	    r, err := os.Open(src)
+//	    escape.On(err)
 //
-//         defer r.Close()
+//	    defer r.Close()
 //
-//         w, err := os.Create(dst)
-//         escape.On(err)
+//	    w, err := os.Create(dst)
+//	    escape.On(err)
 //
-//         defer handle.Chain(&err, func() {
-//             w.Close()
-//             os.Remove(dst)
-//         })
+//	    defer handle.Chain(&err, func() {
+//	        w.Close()
+//	        os.Remove(dst)
+//	    })
 //
-//         _, err = io.Copy(w, r)
-//         escape.On(err)
+//	    _, err = io.Copy(w, r)
+//	    escape.On(err)
 //
-//         return w.Close()
-//     }
+//	    return w.Close()
+//	}
 //
-// WARNINGS
+// Error and Errorf are shorthand for With which accepts options:
+//
+//	escape, hatch := handle.With(&err,
+//	    handle.Region(ctx, "copy"),
+//	    handle.Wrapf("copy %s %s", src, dst),
+//	)
+//	defer hatch()
+//
+// # WARNINGS
 //
 // Mixing handle with other uses of panic/recover is not recommended.
 //
@@ -124,23 +132,42 @@
 //
 // Set Name to the name given to the escape object,
 //
-//     Name=escape
+//	Name=escape
 //
 // and run,
 //
-//     go build -gcflags '-m' 2>&1 | grep -F "${Name}.On escapes to heap"
+//	go build -gcflags '-m' 2>&1 | grep -F "${Name}.On escapes to heap"
 //
 // If you see,
 //
-//     path.go:line:column: ${Name}.On escapes to heap
+//	path.go:line:column: ${Name}.On escapes to heap
 //
 // there is a chance you are doing something that won't end well.
 //
 // Note that this will not detect failure to defer hatch or mixing handle
 // with other uses of panic/recover.
+//
+// At run time, the CheckGoroutine option makes escape.On panic with a clear
+// message when invoked from the wrong goroutine. Enable it for all escapes
+// in debug builds and tests with SetDefaults.
+//
+// An escape that reaches the top of a goroutine because its hatch was not
+// deferred panics with an "unhandled error" that names where escape.On was
+// called. Recording where each escape is created would slow down every call
+// to Error, so the message only names that too when CheckGoroutine is set.
 package handle
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // Chain adds an additional action, fn, to perform when a non-nil error is
 // being returned. Chain must be deferred.
@@ -150,73 +177,618 @@ func Chain(err *error, fn func()) {
 	}
 }
 
+// ChainE is like Chain except that fn receives the error being returned
+// and returns its replacement. This lets a deferred handler convert a
+// low-level error into a domain error. ChainE must be deferred:
+//
+//	defer handle.ChainE(&err, func(e error) error {
+//	    if errors.Is(e, sql.ErrNoRows) {
+//	        return ErrUserNotFound
+//	    }
+//	    return e
+//	})
+func ChainE(err *error, fn func(error) error) {
+	if *err != nil {
+		*err = fn(*err)
+	}
+}
+
+// ChainErr is like Chain except that fn returns an error which, if
+// non-nil, is joined into *err. ChainErr must be deferred:
+//
+//	defer handle.ChainErr(&err, w.Close)
+func ChainErr(err *error, fn func() error) {
+	if *err != nil {
+		if e := fn(); e != nil {
+			*err = errors.Join(*err, e)
+		}
+	}
+}
+
+// Join calls fn and joins any error it returns into *err. Unlike Chain, fn
+// is called even when no error is being returned, which makes Join suitable
+// for functions like Close whose errors matter on the success path. Join
+// must be deferred:
+//
+//	defer handle.Join(&err, f.Close)
+func Join(err *error, fn func() error) {
+	if e := fn(); e != nil {
+		*err = errors.Join(*err, e)
+	}
+}
+
 // Error returns an escape object and a hatch function. When passed a non-nil
 // error, escape.On sets the bound error *err and triggers the deferred hatch
 // function to recover the panic (if there was one) and then, while *err
 // remains non-nil, it calls each function in fns (in reverse order to match
 // the LIFO order of deferred functions).
-func Error(err *error, fns ...func()) (*escape, func()) {
+func Error(err *error, fns ...func()) (*Escape, func()) {
+	if err == nil || hasDefaults() {
+		return With(err, Handlers(fns...))
+	}
+
+	s := &Escape{err: err, fns: fns}
+
+	return s, s.done
+}
+
+// ErrorJoin is like Error except that each function in fns returns an
+// error. A non-nil error returned by a function is joined with the bound
+// error so that failures in handlers, such as removing a partially written
+// file, are not lost:
+//
+//	escape, hatch := handle.ErrorJoin(&err, func() error {
+//	    return os.Remove(dst)
+//	})
+func ErrorJoin(err *error, fns ...func() error) (*Escape, func()) {
+	return With(err, JoinHandlers(fns...))
+}
+
+// Errorf calls Error passing it a function that wraps the error returned.
+func Errorf(err *error, format string, args ...interface{}) (*Escape, func()) {
+	if err == nil || hasDefaults() {
+		return With(err, Wrapf(format, args...))
+	}
+
+	s := &Escape{
+		err:         err,
+		annotations: []annotation{{format, args}},
+		fns: []func(){func() {
+			*err = fmt.Errorf(format+": %w", append(args, *err)...) //nolint:goerr113
+		}},
+	}
+
+	return s, s.done
+}
+
+// With returns an escape object and a hatch function configured by the
+// defaults set with SetDefaults followed by opts. Error and Errorf are
+// shorthand for With with the Handlers and Wrapf options. When there are no
+// defaults, they build the escape directly, without the closures options
+// require, as they are on the success path of every function that uses
+// them.
+func With(err *error, opts ...Option) (*Escape, func()) {
 	var shared error
 
 	if err == nil {
 		err = &shared
 	}
 
-	s := &escape{err: err, fns: fns}
-
-	return s, func() {
-		if s.pnc {
-			s.pnc = false
+	s := &Escape{err: err}
 
-			_ = recover()
-		}
+	applyDefaults(s)
 
-		// Call the error functions in while *s.err is not nil.
-		// Functions are called in reverse order to match defers.
-		for i := len(s.fns) - 1; *s.err != nil && i >= 0; i-- {
-			fns[i]()
-		}
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	s.applyGates()
+
+	return s, s.done
 }
 
-// Errorf calls Error passing it a function that wraps the error returned.
-func Errorf(err *error, format string, args ...interface{}) (*escape, func()) {
-	return Error(err, func() {
-		*err = fmt.Errorf(format+": %w", append(args, *err)...) //nolint:goerr113
-	})
+// Escaper is the interface accepted by helpers that trigger escapes. It is
+// implemented by *Escape and can be implemented by frameworks that want to
+// supply their own behavior, such as counting escapes or recording them in
+// tests, while user code keeps calling the same methods.
+type Escaper interface {
+	// Err returns the bound error.
+	Err() error
+
+	// On triggers an escape if any of errs is non-nil.
+	On(errs ...error)
 }
 
-type escape struct {
-	err *error
-	fns []func()
-	pnc bool
+//nolint:gochecknoglobals
+var _ Escaper = (*Escape)(nil)
+
+// Escape triggers an early return from the function in which its hatch was
+// deferred.
+type Escape struct {
+	err            *error
+	annotations    []annotation
+	async          *Async
+	barriers       []*Barrier
+	budgetWarn     bool
+	catch          bool
+	checkpoint     string
+	cleanups       []cleanup
+	collision      Collision
+	created        [callers]uintptr
+	dedupe         bool
+	escaped        bool
+	exit           []func()
+	final          []func(error) error
+	fns            []func()
+	gates          []func()
+	goroutine      uint64
+	grace          bool
+	group          *Group
+	handlerTimeout time.Duration
+	joinWarnings   bool
+	levels         []levelRule
+	logger         Logger
+	mu             *sync.Mutex
+	muState        atomic.Int32
+	onEscape       []func(error)
+	occurrences    map[uintptr]int
+	op             string
+	order          []string
+	pnc            bool
+	steps          []StepTiming
+	stepStart      time.Time
+	timed          bool
+	timedOut       atomic.Pointer[error]
+	undo           []func()
+	warnings       *[]error
+}
+
+// Err returns the bound error.
+func (s *Escape) Err() error {
+	if err := s.timedOut.Load(); err != nil {
+		return *err
+	}
+
+	return *s.err
 }
 
 // On sets the bound error to the error passed if that error is non-nil and
-// then triggers a panic if one hasn't already been triggered.
-func (s *escape) On(ce error) {
+// then triggers a panic if one hasn't already been triggered. If more than
+// one error is passed, the non-nil errors are joined, in order, as by
+// errors.Join, and Split reports where each was passed. If the bound error
+// is already set to a different error, the Collision option decides which
+// error is kept. After Grace has been called, On records the error as a
+// warning instead.
+func (s *Escape) On(errs ...error) {
+	s.checkGoroutine()
+
+	var ce error
+	if len(errs) == 1 {
+		ce = errs[0]
+	} else {
+		originated := make([]origin, len(errs))
+		for i, err := range errs {
+			originated[i] = originate(err)
+		}
+
+		ce = joinOrigins(originated)
+	}
+
 	if ce != nil {
-		*s.err = ce
+		if s.grace {
+			s.record("escape after grace", ce)
+
+			return
+		}
+
+		s.set(ce)
 
 		// Only panic if we haven't previously.
 		if !s.pnc {
 			s.pnc = true
+			s.escaped = true
 
-			panic(failure{ce})
+			for _, fn := range s.onEscape {
+				fn(ce)
+			}
+
+			f := failure{error: ce, created: s.created}
+			runtime.Callers(2, f.called[:])
+
+			panic(f)
 		}
 	}
 }
 
+// Assert triggers an escape, with an error of the Internal kind formatted
+// using format and args, if cond is false. It replaces ad-hoc panics for
+// invariant violations:
+//
+//	escape.Assert(n >= 0, "negative count %d", n)
+func (s *Escape) Assert(cond bool, format string, args ...interface{}) {
+	if !cond {
+		s.On(Tag(fmt.Errorf(format, args...), Internal)) //nolint:goerr113
+	}
+}
+
+// Escaped reports whether On has triggered an escape. Together with Err it
+// lets code after the hatch, in tests or in functions without a named error
+// return, observe the outcome:
+//
+//	func() {
+//	    defer hatch()
+//	    // ...
+//	}()
+//
+//	if escape.Escaped() {
+//	    // Handle escape.Err().
+//	}
+func (s *Escape) Escaped() bool {
+	return s.escaped
+}
+
+// Recover registers fn as a recovery handler. When the function returns an
+// error, fn is called with it before any handler functions registered
+// earlier and its result replaces the error. Returning nil marks the error
+// as handled: the function returns successfully, with whatever side effects
+// fn applied, and no further handlers are called. Execution does not resume
+// after the call to On.
+func (s *Escape) Recover(fn func(err error) error) {
+	s.fns = append(s.fns, func() {
+		*s.err = fn(*s.err)
+	})
+}
+
+// Onf triggers an escape if err is non-nil after wrapping err with format
+// and args. The function level wrapping added by Errorf is still applied:
+//
+//	escape.Onf(err, "open config %s", path)
+func (s *Escape) Onf(err error, format string, args ...interface{}) {
+	if err != nil {
+		s.On(fmt.Errorf(format+": %w", append(args, err)...)) //nolint:goerr113
+	}
+}
+
+// OnWrap triggers an escape if err is non-nil after replacing err with the
+// result of wrap. This allows domain error types or codes to be attached at
+// the point of failure.
+func (s *Escape) OnWrap(err error, wrap func(error) error) {
+	if err != nil {
+		s.On(wrap(err))
+	}
+}
+
+// Failed sets the bound error to err, if err is non-nil, and returns true
+// without triggering an escape. It is a fast path for performance sensitive
+// loops where the caller returns explicitly but still wants the hatch to
+// apply its wrapping and handler functions on the way out:
+//
+//	if escape.Failed(err) {
+//	    return err
+//	}
+//
+// The returned error is replaced by the bound error when the hatch runs.
+// After Grace has been called, err is recorded as a warning and Failed
+// returns false.
+func (s *Escape) Failed(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if s.grace {
+		s.record("failure after grace", err)
+
+		return false
+	}
+
+	s.set(err)
+
+	return true
+}
+
+// OnIf triggers an escape if err is non-nil and pred returns true for it.
+// This allows the decision to depend on a runtime classification of the
+// error, such as whether it is temporary.
+func (s *Escape) OnIf(err error, pred func(error) bool) {
+	if err != nil && pred(err) {
+		s.On(err)
+	}
+}
+
+// OnN tolerates up to threshold non-nil errors passed to it from the same
+// call site and triggers an escape, with the error annotated with the
+// number of occurrences, once threshold is exceeded. It reports whether err
+// was non-nil so that a retry loop can continue:
+//
+//	for {
+//	    err := try()
+//	    if !escape.OnN(err, 3) {
+//	        break
+//	    }
+//	}
+func (s *Escape) OnN(err error, threshold int) bool {
+	if err == nil {
+		return false
+	}
+
+	var pc [1]uintptr
+
+	runtime.Callers(2, pc[:])
+
+	mu := s.lock()
+
+	if s.occurrences == nil {
+		s.occurrences = map[uintptr]int{}
+	}
+
+	s.occurrences[pc[0]]++
+	n := s.occurrences[pc[0]]
+
+	mu.Unlock()
+
+	if n > threshold {
+		s.On(fmt.Errorf("%w (%d occurrences)", err, n)) //nolint:goerr113
+	}
+
+	return true
+}
+
+// Unless triggers an escape if err is non-nil and does not match, using
+// errors.Is, any of the expected errors:
+//
+//	n, err := r.Read(buf)
+//	escape.Unless(err, io.EOF)
+func (s *Escape) Unless(err error, expected ...error) {
+	for _, target := range expected {
+		if errors.Is(err, target) {
+			return
+		}
+	}
+
+	s.On(err)
+}
+
+// OnErrs triggers an escape if any of errs is non-nil. The non-nil errors
+// are joined, in order, with errors.Join. A single non-nil error is used
+// unchanged. It gives a single escape point for errors collected per item
+// in a loop and is equivalent to escape.On(errs...).
+func (s *Escape) OnErrs(errs []error) {
+	s.On(errs...)
+}
+
+// The states of the mutex that guards the state of an escape shared with
+// other goroutines. The mutex is allocated on first use so that the hatch
+// of an escape which never shares state does not lock anything.
+const (
+	muNone int32 = iota
+	muInit
+	muReady
+)
+
+// lock locks and returns the mutex that guards the state of s shared with
+// other goroutines, allocating it if necessary.
+func (s *Escape) lock() *sync.Mutex {
+	for s.muState.Load() != muReady {
+		if s.muState.CompareAndSwap(muNone, muInit) {
+			s.mu = new(sync.Mutex)
+			s.muState.Store(muReady)
+		} else {
+			runtime.Gosched()
+		}
+	}
+
+	s.mu.Lock()
+
+	return s.mu
+}
+
+// locked is like lock except that it returns nil, without allocating a
+// mutex, if no state has been shared yet.
+func (s *Escape) locked() *sync.Mutex {
+	if s.muState.Load() != muReady {
+		return nil
+	}
+
+	s.mu.Lock()
+
+	return s.mu
+}
+
+// done is the hatch. It must be deferred as recover only stops a panic when
+// called directly by a deferred function.
+func (s *Escape) done() {
+	if s.pnc || s.catch {
+		s.recovered(recover())
+	}
+
+	s.hatch()
+}
+
+func (s *Escape) recovered(r interface{}) {
+	if s.pnc {
+		s.pnc = false
+	} else if r != nil {
+		s.set(newPanicError(r))
+	}
+}
+
+func (s *Escape) hatch() {
+	s.waitChildren()
+	s.drainAsync()
+	s.checkBarriers()
+	s.rollback()
+	s.cleanup()
+
+	s.checkpointed()
+
+	if s.joinWarnings {
+		if ws := s.Warnings(); len(ws) > 0 {
+			*s.err = join(append([]error{*s.err}, ws...))
+		}
+	}
+
+	if s.handlerTimeout > 0 && *s.err != nil {
+		s.handleWithin(s.handlerTimeout)
+	} else {
+		s.handle()
+	}
+
+	for _, fn := range s.exit {
+		fn()
+	}
+}
+
+// handleWithin runs the handler pipeline on another goroutine against a
+// copy of the bound error. If the pipeline does not finish within d, the
+// bound error is left as it was and the pipeline is abandoned. From then
+// on Err returns the bound error, while the abandoned pipeline keeps its
+// copy.
+func (s *Escape) handleWithin(d time.Duration) {
+	bound := s.err
+	raw := *bound
+	handled := raw
+	s.err = &handled
+
+	done := make(chan interface{}, 1)
+
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+
+		s.handle()
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		s.err = bound
+
+		if r != nil {
+			panic(r)
+		}
+
+		*bound = handled
+	case <-timer.C:
+		*bound = raw
+		s.timedOut.Store(bound)
+	}
+}
+
+func (s *Escape) handle() {
+	// Finalizers see the error as joined, before handlers wrap it.
+	for _, fn := range s.final {
+		if *s.err != nil {
+			*s.err = fn(*s.err)
+		}
+	}
+
+	// Call the error functions in while *s.err is not nil.
+	// Functions are called in reverse order to match defers.
+	for i := len(s.fns) - 1; *s.err != nil && i >= 0; i-- {
+		s.fns[i]()
+	}
+}
+
+func (s *Escape) set(err error) {
+	if prev := *s.err; prev != nil && !same(prev, err) {
+		switch s.collision {
+		case KeepFirst:
+			return
+		case JoinErrors:
+			err = errors.Join(prev, err)
+		case KeepLast:
+		}
+	}
+
+	*s.err = err
+}
+
+// join returns nil if all errs are nil, the only non-nil error if there is
+// just one, and errors.Join of the non-nil errors otherwise.
+func join(errs []error) error {
+	var nonNil []error
+
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+
+	if len(nonNil) == 1 {
+		return nonNil[0]
+	}
+
+	return errors.Join(nonNil...)
+}
+
+// same reports whether a and b are the same error value without panicking
+// on errors with dynamic types that are not comparable.
+func same(a, b error) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+
+	return ta == tb && ta.Comparable() && a == b
+}
+
 type failure struct {
 	error
+	called  [callers]uintptr
+	created [callers]uintptr
 }
 
 // Error reports the failure as unhandled when encountered "in the wild".
+// The message includes where On was called and, if the CheckGoroutine
+// option recorded it, where the escape was created to help find the
+// function missing a deferred hatch.
 func (f failure) Error() string {
 	s := "unhandled error"
 	if f.error != nil {
 		s += ": " + f.error.Error()
 	}
 
+	var sites []string
+
+	if site := caller(f.created[:]); site != "" {
+		sites = append(sites, "escape created at "+site)
+	}
+
+	if site := caller(f.called[:]); site != "" {
+		sites = append(sites, "On called at "+site)
+	}
+
+	if len(sites) > 0 {
+		s += " (" + strings.Join(sites, ", ") + ")"
+	}
+
 	return s
 }
+
+func (f failure) Unwrap() error {
+	return f.error
+}
+
+// The number of program counters recorded is enough to get from any
+// function in this package to its caller.
+const callers = 6
+
+//nolint:gochecknoglobals
+var pkg = reflect.TypeOf(Escape{}).PkgPath() + "."
+
+// caller returns the location of the first frame in pcs outside of this
+// package.
+func caller(pcs []uintptr) string {
+	frames := runtime.CallersFrames(pcs)
+
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, pkg) {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
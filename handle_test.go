@@ -1,8 +1,13 @@
 package handle_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
 
 	"github.com/michaelmacinnis/handle"
 )
@@ -50,7 +55,7 @@ func ExampleError_unmodified() {
 }
 
 func Example_annotate() {
-	annotate := func(ef func(error)) func(error, string, ...interface{}) {
+	annotate := func(ef func(...error)) func(error, string, ...interface{}) {
 		return func(err error, format string, args ...interface{}) {
 			if err == nil {
 				return
@@ -80,7 +85,7 @@ func Example_annotate() {
 	// copy(src, dst): call to failure() failed: failure
 }
 
-func ExampleCopyFile_close_dst_err() {
+func Example_copyFileCloseDstErr() {
 	docopy(map[string]error{
 		"close(dst)": errors.New("problem closing dst"),
 	})
@@ -95,7 +100,7 @@ func ExampleCopyFile_close_dst_err() {
 	// copy(src, dst): problem closing dst
 }
 
-func ExampleCopyFile_copy_err() {
+func Example_copyFileCopyErr() {
 	docopy(map[string]error{
 		"copy(dst, src)": errors.New("problem copying"),
 	})
@@ -109,7 +114,7 @@ func ExampleCopyFile_copy_err() {
 	// copy(src, dst): problem copying
 }
 
-func ExampleCopyFile_no_dst() {
+func Example_copyFileNoDst() {
 	docopy(map[string]error{
 		"open(dst)": errors.New("dst not found"),
 	})
@@ -120,7 +125,7 @@ func ExampleCopyFile_no_dst() {
 	// copy(src, dst): dst not found
 }
 
-func ExampleCopyFile_no_error() {
+func Example_copyFileNoError() {
 	docopy(map[string]error{})
 	// Output:
 	// open(src)
@@ -130,7 +135,7 @@ func ExampleCopyFile_no_error() {
 	// close(src)
 }
 
-func ExampleCopyFile_no_src() {
+func Example_copyFileNoSrc() {
 	docopy(map[string]error{
 		"open(src)": errors.New("src not found"),
 	})
@@ -139,7 +144,7 @@ func ExampleCopyFile_no_src() {
 	// copy(src, dst): src not found
 }
 
-func ExampleHandleErr() {
+func ExampleChain_handled() {
 	var err error
 	escape, hatch := handle.Error(&err, func() {
 		fmt.Printf("we should never see this\n")
@@ -163,6 +168,51 @@ func ExampleHandleErr() {
 	// error handled
 }
 
+func ExampleChainE() {
+	errNotFound := errors.New("user not found")
+
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "lookup")
+		defer hatch()
+
+		defer handle.ChainE(&err, func(e error) error {
+			if errors.Is(e, io.EOF) {
+				return errNotFound
+			}
+
+			return e
+		})
+
+		escape.On(io.EOF)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: lookup: user not found
+}
+
+func ExampleChainErr() {
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "write")
+		defer hatch()
+
+		defer handle.ChainErr(&err, func() error {
+			return errors.New("close failed")
+		})
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// write: failure
+	// close failed
+}
+
 func docopy(data map[string]error) {
 	err := mockcopy(data)
 	if err != nil {
@@ -221,3 +271,481 @@ func mockcopy(data map[string]error) (err error) {
 
 	return mock(data, "close(dst)")
 }
+
+type counting struct {
+	handle.Escaper
+	n int
+}
+
+func (c *counting) On(errs ...error) {
+	for _, err := range errs {
+		if err != nil {
+			c.n++
+		}
+	}
+
+	c.Escaper.On(errs...)
+}
+
+func ExampleEscaper() {
+	c := &counting{}
+
+	f := func() (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		c.Escaper = escape
+
+		check := checker(c)
+
+		check(works("World!"))
+		check(fails("World!"))
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(c.n)
+	// Output:
+	// failure
+	// 1
+}
+
+func checker(escape handle.Escaper) func(string, error) {
+	return func(_ string, err error) {
+		escape.On(err)
+	}
+}
+
+func ExampleJoin() {
+	closer := func(err error) func() error {
+		return func() error {
+			fmt.Println("close")
+			return err
+		}
+	}
+
+	f := func(close func() error) (err error) {
+		escape, hatch := handle.Errorf(&err, "f")
+		defer hatch()
+
+		defer handle.Join(&err, close)
+
+		s, err := works("World!")
+		escape.On(err)
+
+		fmt.Println(s)
+
+		return nil
+	}
+
+	fmt.Println(f(closer(nil)))
+	fmt.Println(f(closer(errors.New("close failed"))))
+	// Output:
+	// Hello, World!
+	// close
+	// <nil>
+	// Hello, World!
+	// close
+	// f: close failed
+}
+
+func ExampleErrorJoin() {
+	f := func() (err error) {
+		escape, hatch := handle.ErrorJoin(&err, func() error {
+			return errors.New("remove failed")
+		})
+		defer hatch()
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// failure
+	// remove failed
+}
+
+func ExampleEscape_OnErrs() {
+	validate := func(name string) []error {
+		var errs []error
+
+		if name == "" {
+			errs = append(errs, errors.New("name is empty"))
+		}
+
+		if len(name) < 3 {
+			errs = append(errs, errors.New("name is too short"))
+		}
+
+		return errs
+	}
+
+	f := func(name string) (err error) {
+		escape, hatch := handle.Errorf(&err, "f(%q)", name)
+		defer hatch()
+
+		escape.OnErrs(validate(name))
+
+		return nil
+	}
+
+	fmt.Println(f("World!"))
+	fmt.Println(f(""))
+	// Output:
+	// <nil>
+	// f(""): name is empty
+	// name is too short
+}
+
+func TestUnhandledMessage(t *testing.T) {
+	unhandled := func(opts ...handle.Option) (msg string) {
+		defer func() {
+			err, _ := recover().(error)
+			if err == nil {
+				t.Fatal("expected an unhandled error")
+			}
+
+			msg = err.Error()
+		}()
+
+		var err error
+
+		escape, _ := handle.With(&err, opts...)
+
+		escape.On(errFailure)
+
+		return ""
+	}
+
+	// Only On's call site is recorded by default. Recording where every
+	// escape is created would slow down the success path.
+	msg := unhandled()
+	if want := "unhandled error: failure (On called at "; !strings.HasPrefix(msg, want) {
+		t.Errorf("%q does not start with %q", msg, want)
+	}
+
+	if strings.Contains(msg, "escape created at") {
+		t.Errorf("%q names the creation site without CheckGoroutine", msg)
+	}
+
+	msg = unhandled(handle.CheckGoroutine())
+	for _, want := range []string{
+		"unhandled error: failure (escape created at ",
+		"handle_test.go:",
+		", On called at ",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("%q does not contain %q", msg, want)
+		}
+	}
+}
+
+func ExampleEscape_Onf() {
+	f := func(path string) (err error) {
+		escape, hatch := handle.Errorf(&err, "load")
+		defer hatch()
+
+		_, err = fails(path)
+		escape.Onf(err, "open config %s", path)
+
+		return nil
+	}
+
+	fmt.Println(f("app.json"))
+	// Output: load: open config app.json: failure
+}
+
+func ExampleEscape_OnWrap() {
+	f := func(id string) (err error) {
+		escape, hatch := handle.Errorf(&err, "get user %s", id)
+		defer hatch()
+
+		_, err = fails(id)
+		escape.OnWrap(err, func(err error) error {
+			return handle.Tag(err, handle.NotFound)
+		})
+
+		return nil
+	}
+
+	err := f("42")
+	fmt.Println(err)
+	fmt.Println(handle.KindOf(err))
+	// Output:
+	// get user 42: failure
+	// not found
+}
+
+func ExampleEscape_Escaped() {
+	var err error
+
+	escape, hatch := handle.Errorf(&err, "step")
+
+	func() {
+		defer hatch()
+
+		_, err = fails("World!")
+		escape.On(err)
+	}()
+
+	fmt.Println(escape.Escaped(), escape.Err())
+	// Output: true step: failure
+}
+
+func ExampleEscape_Unless() {
+	f := func(r io.Reader) (n int, err error) {
+		escape, hatch := handle.Errorf(&err, "count")
+		defer hatch()
+
+		buf := make([]byte, 4)
+
+		for err == nil {
+			var m int
+
+			m, err = r.Read(buf)
+			escape.Unless(err, io.EOF)
+
+			n += m
+		}
+
+		return n, nil
+	}
+
+	fmt.Println(f(strings.NewReader("Hello, World!")))
+	fmt.Println(f(iotest.ErrReader(errFailure)))
+	// Output:
+	// 13 <nil>
+	// 0 count: failure
+}
+
+func ExampleEscape_OnIf() {
+	notCanceled := func(err error) bool {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	f := func(err error) (rerr error) {
+		escape, hatch := handle.Error(&rerr)
+		defer hatch()
+
+		escape.OnIf(err, notCanceled)
+
+		return nil
+	}
+
+	fmt.Println(f(context.Canceled))
+	fmt.Println(f(errFailure))
+	// Output:
+	// <nil>
+	// failure
+}
+
+func ExampleEscape_Recover() {
+	f := func() (n int, err error) {
+		escape, hatch := handle.Errorf(&err, "count")
+		defer hatch()
+
+		escape.Recover(func(err error) error {
+			if errors.Is(err, errFailure) {
+				// Fall back to a default.
+				n = -1
+
+				return nil
+			}
+
+			return err
+		})
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return 1, nil
+	}
+
+	fmt.Println(f())
+	// Output: -1 <nil>
+}
+
+func ExampleEscape_On_multiple() {
+	closeAll := func(closers ...func() error) (err error) {
+		escape, hatch := handle.Errorf(&err, "close")
+		defer hatch()
+
+		errs := make([]error, len(closers))
+		for i, c := range closers {
+			errs[i] = c()
+		}
+
+		escape.On(errs...)
+
+		return nil
+	}
+
+	ok := func() error { return nil }
+	bad := func() error { return errFailure }
+
+	fmt.Println(closeAll(ok, ok))
+	fmt.Println(closeAll(ok, bad, bad))
+	// Output:
+	// <nil>
+	// close: failure
+	// failure
+}
+
+func ExampleEscape_OnErrs_loop() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "greet all")
+		defer hatch()
+
+		var errs []error
+
+		for i, name := range names {
+			greet := works
+			if name == "" {
+				greet = fails
+			}
+
+			s, err := greet(name)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("name %d: %w", i, err))
+
+				continue
+			}
+
+			fmt.Println(s)
+		}
+
+		escape.OnErrs(errs)
+
+		return nil
+	}
+
+	fmt.Println(f("a", "", "b", ""))
+	// Output:
+	// Hello, a
+	// Hello, b
+	// greet all: name 1: failure
+	// name 3: failure
+}
+
+func ExampleEscape_Assert() {
+	f := func(n int) (err error) {
+		escape, hatch := handle.Errorf(&err, "f(%d)", n)
+		defer hatch()
+
+		escape.Assert(n >= 0, "negative count %d", n)
+
+		return nil
+	}
+
+	fmt.Println(f(1))
+
+	err := f(-1)
+	fmt.Println(err, handle.KindOf(err))
+	// Output:
+	// <nil>
+	// f(-1): negative count -1 internal
+}
+
+func ExampleEscape_Failed() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "greet")
+		defer hatch()
+
+		for _, name := range names {
+			greet := works
+			if name == "" {
+				greet = fails
+			}
+
+			_, err := greet(name)
+			if escape.Failed(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b"))
+	fmt.Println(f("a", "", "b"))
+	// Output:
+	// <nil>
+	// greet: failure
+}
+
+func ExampleEscape_OnN() {
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "fetch")
+		defer hatch()
+
+		for attempt := 1; ; attempt++ {
+			fmt.Println("attempt", attempt)
+
+			_, err := fails("World!")
+			if !escape.OnN(err, 2) {
+				break
+			}
+		}
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// attempt 1
+	// attempt 2
+	// attempt 3
+	// fetch: failure (3 occurrences)
+}
+
+// The success path of Error and Errorf is the cost paid by every function
+// that uses handle, so it is kept off the option machinery used by With.
+
+func BenchmarkError(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = func() (err error) {
+			escape, hatch := handle.Error(&err)
+			defer hatch()
+
+			escape.On(nil)
+
+			return nil
+		}()
+	}
+}
+
+func BenchmarkErrorf(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = func() (err error) {
+			escape, hatch := handle.Errorf(&err, "f(%s)", "name")
+			defer hatch()
+
+			escape.On(nil)
+
+			return nil
+		}()
+	}
+}
+
+func BenchmarkWith(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = func() (err error) {
+			escape, hatch := handle.With(&err, handle.Wrapf("f(%s)", "name"))
+			defer hatch()
+
+			escape.On(nil)
+
+			return nil
+		}()
+	}
+}
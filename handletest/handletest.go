@@ -0,0 +1,143 @@
+// Package handletest provides utilities for testing code built on the
+// handle package, including a conformance suite for Escaper
+// implementations.
+package handletest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+// Factory creates an Escaper and hatch bound to err with the handler
+// functions fns, following the contract of handle.Error.
+type Factory func(err *error, fns ...func()) (handle.Escaper, func())
+
+var errTest = errors.New("test")
+
+// TestEscaper checks that the Escaper implementation created by factory
+// behaves like the one returned by handle.Error.
+func TestEscaper(t *testing.T, factory Factory) {
+	t.Helper()
+
+	t.Run("escape", func(t *testing.T) {
+		reached := false
+
+		err := func() (err error) {
+			escape, hatch := factory(&err)
+			defer hatch()
+
+			escape.On(errTest)
+
+			reached = true
+
+			return nil
+		}()
+
+		if reached {
+			t.Error("On(err) did not escape")
+		}
+
+		if !errors.Is(err, errTest) {
+			t.Errorf("got %v, want %v", err, errTest)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		called := false
+
+		err := func() (err error) {
+			escape, hatch := factory(&err, func() {
+				called = true
+			})
+			defer hatch()
+
+			escape.On(nil)
+
+			if escape.Err() != nil {
+				t.Errorf("Err() = %v, want nil", escape.Err())
+			}
+
+			return nil
+		}()
+
+		if err != nil {
+			t.Errorf("got %v, want nil", err)
+		}
+
+		if called {
+			t.Error("handler called without an error")
+		}
+	})
+
+	t.Run("wrap-order", func(t *testing.T) {
+		wrap := func(err *error, s string) func() {
+			return func() {
+				*err = fmt.Errorf("%s: %w", s, *err)
+			}
+		}
+
+		err := func() (err error) {
+			escape, hatch := factory(&err, wrap(&err, "outer"), wrap(&err, "inner"))
+			defer hatch()
+
+			escape.On(errTest)
+
+			return nil
+		}()
+
+		if got, want := fmt.Sprint(err), "outer: inner: test"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+
+		if !errors.Is(err, errTest) {
+			t.Errorf("%v does not wrap %v", err, errTest)
+		}
+	})
+
+	t.Run("handler-order", func(t *testing.T) {
+		var order []int
+
+		record := func(n int) func() {
+			return func() {
+				order = append(order, n)
+			}
+		}
+
+		_ = func() (err error) {
+			escape, hatch := factory(&err, record(1), func() {
+				order = append(order, 2)
+				err = nil
+			}, record(3))
+			defer hatch()
+
+			escape.On(errTest)
+
+			return nil
+		}()
+
+		if want := []int{3, 2}; !reflect.DeepEqual(order, want) {
+			t.Errorf("got %v, want %v", order, want)
+		}
+	})
+
+	t.Run("panic-interop", func(t *testing.T) {
+		const value = "foreign"
+
+		defer func() {
+			if r := recover(); r != value {
+				t.Errorf("recovered %v, want %v", r, value)
+			}
+		}()
+
+		func() {
+			_, hatch := factory(nil)
+			defer hatch()
+
+			panic(value)
+		}()
+	})
+}
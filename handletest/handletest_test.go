@@ -0,0 +1,55 @@
+package handletest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/michaelmacinnis/handle"
+	"github.com/michaelmacinnis/handle/handletest"
+)
+
+func TestError(t *testing.T) {
+	handletest.TestEscaper(t, func(err *error, fns ...func()) (handle.Escaper, func()) {
+		return handle.Error(err, fns...)
+	})
+}
+
+func TestNew(t *testing.T) {
+	handletest.TestEscaper(t, func(err *error, fns ...func()) (handle.Escaper, func()) {
+		h := handle.New(err, handle.Handlers(fns...))
+
+		return h, h.Done
+	})
+}
+
+func TestSequence(t *testing.T) {
+	var q handletest.Sequence
+
+	err := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.Handlers(q.Func("handler", &err, nil)),
+			handle.Wrapf("wrapped"),
+		)
+		defer hatch()
+
+		defer handle.Chain(&err, q.Func("chain", &err, nil))
+
+		escape.Cleanup("", q.Func("cleanup", &err, nil))
+		escape.Undo(q.Func("undo", &err, nil))
+
+		escape.On(errors.New("failure"))
+
+		return nil
+	}()
+
+	q.Expect(t, "chain", "undo", "cleanup", "handler")
+
+	steps := q.Steps()
+	if last := steps[len(steps)-1]; last.Err == nil || last.Err.Error() != "wrapped: failure" {
+		t.Errorf("handler saw %v, want wrapped: failure", last.Err)
+	}
+
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
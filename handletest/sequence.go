@@ -0,0 +1,73 @@
+package handletest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// Step is a recorded execution of a handler, cleanup or other function.
+type Step struct {
+	Name string
+
+	// Err is the value of the bound error when the step ran.
+	Err error
+}
+
+// Sequence records the order in which functions run, and the error at
+// each step, so that tests can assert on the interaction of handlers,
+// cleanups and undo functions during a hatch. It is safe for concurrent
+// use.
+type Sequence struct {
+	mu    sync.Mutex
+	steps []Step
+}
+
+// Func returns a function that records a step called name, along with the
+// current value of *err, and then calls fn if it is not nil.
+func (q *Sequence) Func(name string, err *error, fn func()) func() {
+	return func() {
+		step := Step{Name: name}
+		if err != nil {
+			step.Err = *err
+		}
+
+		q.mu.Lock()
+		q.steps = append(q.steps, step)
+		q.mu.Unlock()
+
+		if fn != nil {
+			fn()
+		}
+	}
+}
+
+// Steps returns the steps recorded so far.
+func (q *Sequence) Steps() []Step {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]Step{}, q.steps...)
+}
+
+// Names returns the names of the steps recorded so far.
+func (q *Sequence) Names() []string {
+	steps := q.Steps()
+
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.Name
+	}
+
+	return names
+}
+
+// Expect reports an error on t if the names of the steps recorded are not
+// exactly names.
+func (q *Sequence) Expect(t testing.TB, names ...string) {
+	t.Helper()
+
+	if got := q.Names(); !slices.Equal(got, names) {
+		t.Errorf("got steps %q, want %q", got, names)
+	}
+}
@@ -0,0 +1,97 @@
+package handle
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var errNilResponse = errors.New("nil response")
+
+// StatusError reports an HTTP response with a status that was not accepted.
+// RetryAfter is taken from the Retry-After header, if the response had one.
+// Any password in URL is redacted as the error may be logged.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	s := e.Status
+	if s == "" {
+		s = strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+	}
+
+	if e.Method != "" || e.URL != "" {
+		s = e.Method + " " + e.URL + ": " + s
+	}
+
+	return s
+}
+
+// Kind returns ResourceExhausted for a 429 Too Many Requests response.
+func (e *StatusError) Kind() Kind {
+	if e.StatusCode == http.StatusTooManyRequests {
+		return ResourceExhausted
+	}
+
+	return ""
+}
+
+func (e *StatusError) Is(target error) bool {
+	k, ok := target.(Kind)
+
+	return ok && k != "" && k == e.Kind()
+}
+
+// OnStatus triggers an escape with a *StatusError if the status code of resp
+// is not one of accepted.
+func (s *Escape) OnStatus(resp *http.Response, accepted ...int) {
+	if resp == nil {
+		s.On(errNilResponse)
+
+		return
+	}
+
+	for _, code := range accepted {
+		if resp.StatusCode == code {
+			return
+		}
+	}
+
+	e := &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After")),
+	}
+	if req := resp.Request; req != nil {
+		e.Method = req.Method
+		if req.URL != nil {
+			e.URL = req.URL.Redacted()
+		}
+	}
+
+	s.On(e)
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+
+	return 0
+}
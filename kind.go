@@ -0,0 +1,299 @@
+package handle
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Kind classifies an error. A Kind is itself an error so that it can be
+// used as the target of errors.Is:
+//
+//	if errors.Is(err, handle.NotFound) {
+//	    // ...
+//	}
+type Kind string
+
+// Kinds registered by default.
+const (
+	Bug               Kind = "bug"
+	Internal          Kind = "internal"
+	InvalidArgument   Kind = "invalid argument"
+	NotFound          Kind = "not found"
+	ResourceExhausted Kind = "resource exhausted"
+)
+
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// KindOf returns the Kind of the first error in err's chain that has one.
+// Failing that, it returns the first Kind equated, by Equate, with an error
+// in err's chain, or the empty Kind if there is no such Kind.
+func KindOf(err error) Kind {
+	var k interface{ Kind() Kind }
+	if errors.As(err, &k) {
+		return k.Kind()
+	}
+
+	return registry.equated(err)
+}
+
+// Tag returns err with the Kind k. It returns nil if err is nil.
+func Tag(err error, k Kind) error {
+	if err == nil {
+		return nil
+	}
+
+	return &kindError{err, k}
+}
+
+// KindInfo describes a Kind for adapters and documentation generators.
+type KindInfo struct {
+	Kind        Kind
+	Code        string
+	Description string
+	Remediation string
+	HTTPStatus  int
+	GRPCCode    int
+}
+
+// KindRegistry holds the Kinds declared by an application.
+type KindRegistry struct {
+	mu    sync.RWMutex
+	equiv []equivalence
+	kinds map[Kind]KindInfo
+}
+
+type equivalence struct {
+	kind   Kind
+	target error
+}
+
+//nolint:gochecknoglobals
+var registry = &KindRegistry{kinds: map[Kind]KindInfo{}}
+
+//nolint:gochecknoinits
+func init() {
+	registry.Register(
+		KindInfo{
+			Kind:        Bug,
+			Code:        "BUG",
+			Description: "An error that should not have been possible occurred.",
+			Remediation: "Report this, with the error and stack, to the maintainers.",
+			HTTPStatus:  http.StatusInternalServerError,
+			GRPCCode:    13,
+		},
+		KindInfo{
+			Kind:        Internal,
+			Code:        "INTERNAL",
+			Description: "An unexpected condition was encountered.",
+			Remediation: "Retry later. If the problem persists, report it.",
+			HTTPStatus:  http.StatusInternalServerError,
+			GRPCCode:    13,
+		},
+		KindInfo{
+			Kind:        InvalidArgument,
+			Code:        "INVALID_ARGUMENT",
+			Description: "The caller supplied an invalid argument.",
+			Remediation: "Check the arguments and try again.",
+			HTTPStatus:  http.StatusBadRequest,
+			GRPCCode:    3,
+		},
+		KindInfo{
+			Kind:        NotFound,
+			Code:        "NOT_FOUND",
+			Description: "The requested entity was not found.",
+			Remediation: "Check that the name or ID is correct and that it exists.",
+			HTTPStatus:  http.StatusNotFound,
+			GRPCCode:    5,
+		},
+		KindInfo{
+			Kind:        ResourceExhausted,
+			Code:        "RESOURCE_EXHAUSTED",
+			Description: "A rate limit or quota was exceeded.",
+			Remediation: "Wait before retrying or request a higher quota.",
+			HTTPStatus:  http.StatusTooManyRequests,
+			GRPCCode:    8,
+		},
+	)
+}
+
+// Registry returns the process-wide Kind registry.
+func Registry() *KindRegistry {
+	return registry
+}
+
+// Kinds returns information for each registered Kind, sorted by Kind.
+func (r *KindRegistry) Kinds() []KindInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]KindInfo, 0, len(r.kinds))
+	for _, info := range r.kinds {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Kind < infos[j].Kind
+	})
+
+	return infos
+}
+
+// Lookup returns the information registered for k.
+func (r *KindRegistry) Lookup(k Kind) (KindInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.kinds[k]
+
+	return info, ok
+}
+
+// Register declares each Kind in infos, replacing any previous declaration.
+func (r *KindRegistry) Register(infos ...KindInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, info := range infos {
+		r.kinds[info.Kind] = info
+	}
+}
+
+// Unregister removes the declarations of kinds, along with any
+// equivalences declared for them with Equate. It lets tests and examples
+// undo changes to the process-wide registry:
+//
+//	handle.Registry().Register(info)
+//	defer handle.Registry().Unregister(info.Kind)
+func (r *KindRegistry) Unregister(kinds ...Kind) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, k := range kinds {
+		delete(r.kinds, k)
+	}
+
+	r.removeEquivalences(func(e equivalence) bool {
+		for _, k := range kinds {
+			if e.kind == k {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Equate declares that errors of Kind k are equivalent to each of targets.
+// An error tagged with k then matches errors.Is(err, target), and KindOf
+// reports k for an untagged error that matches errors.Is(err, target).
+// This bridges Kinds and the standard library sentinels that existing
+// callers already check:
+//
+//	handle.Registry().Equate(handle.NotFound, fs.ErrNotExist)
+//
+// As errors.Is consults only the error and not the target,
+// errors.Is(err, handle.NotFound) remains false for an untagged error. Use
+// KindOf to classify such errors. Declaring an equivalence more than once
+// has no further effect.
+func (r *KindRegistry) Equate(k Kind, targets ...error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, target := range targets {
+		if !r.equivalentLocked(k, target) {
+			r.equiv = append(r.equiv, equivalence{k, target})
+		}
+	}
+}
+
+// Unequate removes the equivalences declared by Equate between k and each
+// of targets.
+func (r *KindRegistry) Unequate(k Kind, targets ...error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.removeEquivalences(func(e equivalence) bool {
+		for _, target := range targets {
+			if e.kind == k && same(e.target, target) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// removeEquivalences removes the equivalences for which remove returns
+// true. The slice is copied, rather than filtered in place, as equated
+// reads it without holding the lock.
+func (r *KindRegistry) removeEquivalences(remove func(equivalence) bool) {
+	var kept []equivalence
+
+	for _, e := range r.equiv {
+		if !remove(e) {
+			kept = append(kept, e)
+		}
+	}
+
+	r.equiv = kept
+}
+
+func (r *KindRegistry) equated(err error) Kind {
+	if err == nil {
+		return ""
+	}
+
+	r.mu.RLock()
+	equiv := r.equiv
+	r.mu.RUnlock()
+
+	for _, e := range equiv {
+		if errors.Is(err, e.target) {
+			return e.kind
+		}
+	}
+
+	return ""
+}
+
+func (r *KindRegistry) equivalent(k Kind, target error) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.equivalentLocked(k, target)
+}
+
+func (r *KindRegistry) equivalentLocked(k Kind, target error) bool {
+	for _, e := range r.equiv {
+		if e.kind == k && same(e.target, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type kindError struct {
+	error
+	kind Kind
+}
+
+func (e *kindError) Is(target error) bool {
+	if k, ok := target.(Kind); ok {
+		return k == e.kind
+	}
+
+	return registry.equivalent(e.kind, target)
+}
+
+func (e *kindError) Kind() Kind {
+	return e.kind
+}
+
+func (e *kindError) Unwrap() error {
+	return e.error
+}
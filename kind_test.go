@@ -0,0 +1,60 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleKindRegistry_Kinds() {
+	const Conflict handle.Kind = "conflict"
+
+	handle.Registry().Register(handle.KindInfo{
+		Kind:        Conflict,
+		Code:        "CONFLICT",
+		Description: "The request conflicts with the current state.",
+		HTTPStatus:  409,
+		GRPCCode:    10,
+	})
+	defer handle.Registry().Unregister(Conflict)
+
+	for _, info := range handle.Registry().Kinds() {
+		fmt.Printf("%s %d %d\n", info.Code, info.HTTPStatus, info.GRPCCode)
+	}
+	// Output:
+	// BUG 500 13
+	// CONFLICT 409 10
+	// INTERNAL 500 13
+	// INVALID_ARGUMENT 400 3
+	// NOT_FOUND 404 5
+	// RESOURCE_EXHAUSTED 429 8
+}
+
+func ExampleTag() {
+	err := handle.Tag(errors.New("no such user"), handle.NotFound)
+
+	fmt.Println(err)
+	fmt.Println(handle.KindOf(fmt.Errorf("lookup: %w", err)))
+	fmt.Println(errors.Is(err, handle.NotFound))
+	// Output:
+	// no such user
+	// not found
+	// true
+}
+
+func ExampleKindRegistry_Equate() {
+	handle.Registry().Equate(handle.NotFound, fs.ErrNotExist)
+	defer handle.Registry().Unequate(handle.NotFound, fs.ErrNotExist)
+
+	err := handle.Tag(errors.New("no such user"), handle.NotFound)
+	fmt.Println(errors.Is(err, fs.ErrNotExist))
+
+	_, err = os.Open("/does/not/exist")
+	fmt.Println(handle.KindOf(err))
+	// Output:
+	// true
+	// not found
+}
@@ -0,0 +1,110 @@
+package handle
+
+import (
+	"context"
+	"sync"
+)
+
+// Map calls fn for each of items using at most n goroutines and returns
+// the results in the order of items. Errors are annotated, like those of
+// ForRange, with the element's index, and key if configured, in an
+// *ItemError. By default the first failure cancels the context passed to
+// fn, no further items are started and the escape is triggered with that
+// failure. With the Accumulate option, every item is processed and the
+// escape is triggered with the failures joined in index order:
+//
+//	sizes := handle.Map(ctx, escape, urls, 8, func(ctx context.Context, url string) (int64, error) {
+//	    return fetchSize(ctx, url)
+//	})
+func Map[T, R any](ctx context.Context, escape Escaper, items []T, n int, fn func(context.Context, T) (R, error), opts ...RangeOption) []R {
+	c := newRangeConfig(opts)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		errs    = make([]error, len(items))
+		first   error
+		mu      sync.Mutex
+		results = make([]R, len(items))
+		sem     = make(chan struct{}, max(n, 1))
+		started int
+		wg      sync.WaitGroup
+	)
+
+	for i, item := range items {
+		sem <- struct{}{}
+
+		if !c.accumulate && ctx.Err() != nil {
+			break
+		}
+
+		started++
+
+		wg.Add(1)
+
+		go func(i int, item T) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			r, err := fn(ctx, item)
+			results[i] = r
+
+			if err = c.item(i, item, err); err != nil {
+				errs[i] = err
+
+				if !c.accumulate {
+					mu.Lock()
+					if first == nil {
+						first = err
+						cancel(err)
+					}
+					mu.Unlock()
+				}
+			}
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	switch {
+	case c.accumulate:
+		escape.On(join(errs))
+	case first != nil:
+		escape.On(first)
+	case started < len(items):
+		escape.On(context.Cause(ctx))
+	}
+
+	return results
+}
+
+// ForEach calls fn for each of items using at most n goroutines. Each call
+// gets its own escape and a context that is canceled when any call fails.
+// Failures are handled as for Map. ForEach returns only once every worker
+// has exited, so handlers and Chain functions in the parent never race with
+// workers:
+//
+//	handle.ForEach(ctx, escape, files, 4, func(ctx context.Context, escape *handle.Escape, name string) {
+//	    escape.On(upload(ctx, name))
+//	})
+func ForEach[T any](ctx context.Context, escape Escaper, items []T, n int, fn func(context.Context, *Escape, T), opts ...RangeOption) {
+	Map(ctx, escape, items, n, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, call(func(escape *Escape) {
+			fn(ctx, escape, item)
+		})
+	}, opts...)
+}
+
+// call calls fn with an escape whose hatch is deferred for it and returns
+// the resulting error.
+func call(fn func(*Escape), opts ...Option) (err error) {
+	escape, hatch := With(&err, opts...)
+	defer hatch()
+
+	fn(escape)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleMap() {
+	f := func(opts ...handle.RangeOption) (err error) {
+		escape, hatch := handle.Errorf(&err, "parse")
+		defer hatch()
+
+		inputs := []string{"1", "x", "3", "y"}
+
+		ns := handle.Map(context.Background(), escape, inputs, 1, func(_ context.Context, s string) (int, error) {
+			return strconv.Atoi(s)
+		}, opts...)
+
+		fmt.Println(ns)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(f(handle.Accumulate()))
+	// Output:
+	// parse: item 1: strconv.Atoi: parsing "x": invalid syntax
+	// parse: item 1: strconv.Atoi: parsing "x": invalid syntax
+	// item 3: strconv.Atoi: parsing "y": invalid syntax
+}
+
+func ExampleForEach() {
+	f := func(names ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "upload")
+		defer hatch()
+
+		handle.ForEach(context.Background(), escape, names, 2, func(ctx context.Context, escape *handle.Escape, name string) {
+			escape.OnCtx(ctx)
+
+			if name == "" {
+				_, err := fails(name)
+				escape.On(err)
+			}
+		})
+
+		return nil
+	}
+
+	fmt.Println(f("a", "b", "c"))
+	fmt.Println(f("a", "", "c"))
+	// Output:
+	// <nil>
+	// upload: item 1: failure
+}
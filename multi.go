@@ -0,0 +1,85 @@
+package handle
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// Finalize adds fn to the functions the hatch calls, in order, before any
+// handler functions. Each receives the non-nil error being returned, as
+// joined by On, and returns its replacement. Filter, Distinct and Sort are
+// useful here.
+func Finalize(fn func(err error) error) Option {
+	return func(s *Escape) {
+		s.final = append(s.final, fn)
+	}
+}
+
+// Filter returns err without the joined errors for which keep returns
+// false. If err is not a joined error, keep is applied to err itself.
+func Filter(err error, keep func(error) bool) error {
+	var errs []error
+
+	for _, e := range split(err) {
+		if keep(e) {
+			errs = append(errs, e)
+		}
+	}
+
+	return join(errs)
+}
+
+// Distinct returns err without joined errors whose messages duplicate an
+// earlier one.
+func Distinct(err error) error {
+	seen := map[string]bool{}
+
+	return Filter(err, func(e error) bool {
+		msg := e.Error()
+		if seen[msg] {
+			return false
+		}
+
+		seen[msg] = true
+
+		return true
+	})
+}
+
+// Sort returns err with its joined errors sorted by less. The sort is
+// stable.
+func Sort(err error, less func(a, b error) bool) error {
+	errs := split(err)
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return less(errs[i], errs[j])
+	})
+
+	return join(errs)
+}
+
+//nolint:gochecknoglobals
+var stdJoinType = reflect.TypeOf(errors.Join(errors.New("")))
+
+// split returns a copy of the errors joined in err, or err alone if it is
+// not a joined error. Only errors joined by this package or by errors.Join
+// are split. Other errors that wrap several errors, such as those added by
+// Sentinel, are kept whole.
+func split(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	if je, ok := err.(*joinError); ok { //nolint:errorlint
+		return je.Unwrap()
+	}
+
+	if reflect.TypeOf(err) == stdJoinType {
+		if u, ok := err.(interface{ Unwrap() []error }); ok {
+			return append([]error{}, u.Unwrap()...)
+		}
+	}
+
+	return []error{err}
+}
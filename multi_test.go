@@ -0,0 +1,123 @@
+package handle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleFinalize() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err, handle.Finalize(func(err error) error {
+			err = handle.Filter(err, func(e error) bool {
+				return !errors.Is(e, context.Canceled)
+			})
+
+			return handle.Distinct(err)
+		}))
+		defer hatch()
+
+		escape.OnErrs([]error{
+			errors.New("disk full"),
+			context.Canceled,
+			errors.New("disk full"),
+			errors.New("quota exceeded"),
+		})
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// disk full
+	// quota exceeded
+}
+
+func ExampleFinalize_sentinel() {
+	errStorage := errors.New("storage")
+
+	f := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.Sentinel(errStorage),
+			handle.Finalize(handle.Distinct),
+		)
+		defer hatch()
+
+		escape.On(errors.New("disk"))
+
+		return nil
+	}
+
+	err := f()
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, errStorage))
+	// Output:
+	// disk
+	// true
+}
+
+func ExampleFinalize_wrapf() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.Wrapf("op"),
+			handle.Finalize(handle.Distinct),
+		)
+		defer hatch()
+
+		a := errors.New("a")
+		escape.On(a, a)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: op: a
+}
+
+func ExampleFilter() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err, handle.Finalize(func(err error) error {
+			// The joined errors are those passed to On, so they can be
+			// compared directly.
+			return handle.Filter(err, func(e error) bool {
+				return e != io.EOF //nolint:errorlint
+			})
+		}))
+		defer hatch()
+
+		escape.On(io.EOF, errFailure)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: failure
+}
+
+func ExampleSort() {
+	err := errors.Join(
+		errors.New("warning: slow"),
+		errors.New("fatal: corrupt"),
+		errors.New("warning: retried"),
+	)
+
+	severity := func(e error) int {
+		if strings.HasPrefix(e.Error(), "fatal") {
+			return 0
+		}
+
+		return 1
+	}
+
+	fmt.Println(handle.Sort(err, func(a, b error) bool {
+		return severity(a) < severity(b)
+	}))
+	// Output:
+	// fatal: corrupt
+	// warning: slow
+	// warning: retried
+}
@@ -0,0 +1,136 @@
+// Package netx provides helpers for network code that reports failures
+// through a handle.Escaper. Errors carry the remote address and timeouts
+// are tagged with the Timeout Kind.
+package netx
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+// Timeout is the Kind of errors caused by a deadline passing.
+const Timeout handle.Kind = "timeout"
+
+//nolint:gochecknoinits
+func init() {
+	handle.Registry().Register(handle.KindInfo{
+		Kind:        Timeout,
+		Code:        "DEADLINE_EXCEEDED",
+		Description: "A network operation did not complete before its deadline.",
+		Remediation: "Retry, possibly with a longer deadline.",
+		HTTPStatus:  http.StatusGatewayTimeout,
+		GRPCCode:    4,
+	})
+}
+
+// Error records the remote address of a failed network operation.
+type Error struct {
+	Addr string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Addr + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Dial connects to addr on network, triggering an escape if ctx is done or
+// the connection cannot be made. The connection is closed by the hatch if
+// the function returns an error.
+func Dial(ctx context.Context, escape *handle.Escape, network, addr string) net.Conn {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, network, addr)
+	escape.On(wrap(addr, err))
+
+	escape.Undo(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+// Deadline sets the deadline for conn to d from now or to the deadline of
+// ctx, whichever is earlier. A d of zero or less means no timeout beyond
+// that of ctx. Failure to set the deadline triggers an escape.
+func Deadline(ctx context.Context, escape handle.Escaper, conn net.Conn, d time.Duration) {
+	var t time.Time
+	if d > 0 {
+		t = time.Now().Add(d)
+	}
+
+	if dl, ok := ctx.Deadline(); ok && (t.IsZero() || dl.Before(t)) {
+		t = dl
+	}
+
+	escape.On(Wrap(conn, conn.SetDeadline(t)))
+}
+
+// Read reads from conn into p, triggering an escape with an error wrapped
+// by Wrap if the read fails.
+func Read(escape handle.Escaper, conn net.Conn, p []byte) int {
+	n, err := conn.Read(p)
+	escape.On(Wrap(conn, err))
+
+	return n
+}
+
+// Write writes p to conn, triggering an escape with an error wrapped by
+// Wrap if the write fails.
+func Write(escape handle.Escaper, conn net.Conn, p []byte) int {
+	n, err := conn.Write(p)
+	escape.On(Wrap(conn, err))
+
+	return n
+}
+
+// Wrap returns err with the remote address of conn and, if err is a
+// timeout, the Kind Timeout. Errors from the net package that already
+// include the address are not wrapped again. Wrap returns nil if err is nil.
+func Wrap(conn net.Conn, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	addr := ""
+	if a := conn.RemoteAddr(); a != nil {
+		addr = a.String()
+	}
+
+	return wrap(addr, err)
+}
+
+func wrap(addr string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var op *net.OpError
+	if addr != "" && !errors.As(err, &op) {
+		err = &Error{addr, err}
+	}
+
+	if timeout(err) {
+		err = handle.Tag(err, Timeout)
+	}
+
+	return err
+}
+
+func timeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var t interface{ Timeout() bool }
+
+	return errors.As(err, &t) && t.Timeout()
+}
@@ -0,0 +1,73 @@
+package netx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+	"github.com/michaelmacinnis/handle/netx"
+)
+
+func Example() {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	var conn net.Conn
+
+	f := func(ctx context.Context) (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		conn = netx.Dial(ctx, escape, "tcp", ln.Addr().String())
+		netx.Deadline(ctx, escape, conn, 10*time.Millisecond)
+
+		netx.Write(escape, conn, []byte("ping"))
+
+		// The server never replies so the read times out.
+		netx.Read(escape, conn, make([]byte, 4))
+
+		return nil
+	}
+
+	err = f(context.Background())
+	fmt.Println(handle.KindOf(err))
+	fmt.Println(errors.Is(err, netx.Timeout))
+
+	// The connection was closed by the hatch.
+	_, err = conn.Write([]byte("ping"))
+	fmt.Println(errors.Is(err, net.ErrClosed))
+	// Output:
+	// timeout
+	// true
+	// true
+}
+
+func ExampleWrap() {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	server.Close()
+
+	_, err := client.Read(make([]byte, 1))
+	fmt.Println(netx.Wrap(client, err))
+	// Output: pipe: EOF
+}
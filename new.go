@@ -0,0 +1,37 @@
+package handle
+
+// Handle combines an escape and its hatch in a single object:
+//
+//	func do(name string) (err error) {
+//	    h := handle.New(&err, handle.Wrapf("do(%s)", name))
+//	    defer h.Done()
+//
+//	    f := handle.Check(os.Open(name))(h)
+//	    defer f.Close()
+//
+//	    // ...
+//
+//	    return nil
+//	}
+//
+// Go methods cannot have type parameters, so values are checked with the
+// generic Check functions, which accept a Handle as an Escaper.
+type Handle struct {
+	*Escape
+}
+
+// New returns a Handle for the bound error err configured by opts.
+func New(err *error, opts ...Option) *Handle {
+	s, _ := With(err, opts...)
+
+	return &Handle{s}
+}
+
+// Done is the hatch for h and must be deferred.
+func (h *Handle) Done() {
+	if h.pnc || h.catch {
+		h.recovered(recover())
+	}
+
+	h.hatch()
+}
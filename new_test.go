@@ -0,0 +1,25 @@
+package handle_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleNew() {
+	double := func(s string) (n int, err error) {
+		h := handle.New(&err, handle.Wrapf("double(%q)", s))
+		defer h.Done()
+
+		n = handle.Check(strconv.Atoi(s))(h)
+
+		return 2 * n, nil
+	}
+
+	fmt.Println(double("21"))
+	fmt.Println(double("twenty-one"))
+	// Output:
+	// 42 <nil>
+	// 0 double("twenty-one"): strconv.Atoi: parsing "twenty-one": invalid syntax
+}
@@ -0,0 +1,30 @@
+package handle
+
+// Off returns an Escaper that never triggers an escape. Its On method sets
+// *err to a non-nil error and returns, leaving the caller to check Err and
+// handle the error manually. This lets generated code and hot paths share
+// helpers written against Escaper without panic-based control flow. Note
+// that helpers keep running after On returns.
+func Off(err *error) Escaper {
+	var shared error
+
+	if err == nil {
+		err = &shared
+	}
+
+	return off{err}
+}
+
+type off struct {
+	err *error
+}
+
+func (o off) Err() error {
+	return *o.err
+}
+
+func (o off) On(errs ...error) {
+	if err := join(errs); err != nil {
+		*o.err = err
+	}
+}
@@ -0,0 +1,24 @@
+package handle_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleOff() {
+	parse := func(escape handle.Escaper, s string) int {
+		return handle.Check(strconv.Atoi(s))(escape)
+	}
+
+	var err error
+
+	escape := handle.Off(&err)
+
+	n := parse(escape, "x")
+	if err != nil {
+		fmt.Println(n, err)
+	}
+	// Output: 0 strconv.Atoi: parsing "x": invalid syntax
+}
@@ -0,0 +1,174 @@
+package handle
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Option configures an Escape.
+type Option func(*Escape)
+
+// Handlers adds fns to the functions called, in reverse order, by the hatch
+// while the bound error is non-nil.
+func Handlers(fns ...func()) Option {
+	return func(s *Escape) {
+		s.fns = append(s.fns, fns...)
+	}
+}
+
+// JoinHandlers adds fns to the handler functions called by the hatch. Any
+// non-nil error returned by a function is joined with the bound error.
+func JoinHandlers(fns ...func() error) Option {
+	return func(s *Escape) {
+		for _, fn := range fns {
+			fn := fn
+
+			s.fns = append(s.fns, func() {
+				if err := fn(); err != nil {
+					*s.err = errors.Join(*s.err, err)
+				}
+			})
+		}
+	}
+}
+
+// Wrapf adds a handler that wraps the bound error using format and args.
+func Wrapf(format string, args ...interface{}) Option {
+	return func(s *Escape) {
+		s.annotations = append(s.annotations, annotation{format, args})
+		s.fns = append(s.fns, func() {
+			if s.dedupe {
+				prefix := fmt.Sprintf(format, args...) + ": "
+				if strings.HasPrefix((*s.err).Error(), prefix) {
+					return
+				}
+			}
+
+			*s.err = fmt.Errorf(format+": %w", append(args, *s.err)...) //nolint:goerr113
+		})
+	}
+}
+
+// annotation records the format and args passed to Wrapf so that they are
+// only formatted when described.
+type annotation struct {
+	format string
+	args   []interface{}
+}
+
+// Dedupe stops Wrapf, and so Errorf, from adding an annotation identical
+// to the one the error already starts with. When a helper and its caller
+// both annotate with the same operation name, this turns
+//
+//	save user: save user: connection reset
+//
+// into "save user: connection reset". It is most useful set by SetDefaults.
+func Dedupe() Option {
+	return func(s *Escape) {
+		s.dedupe = true
+	}
+}
+
+// Collision determines what happens when an escape is triggered while the
+// bound error is already set to a different error. This happens when code
+// assigns to the bound error directly and then calls escape.On with another
+// error, or when escape.On is called again by a deferred function.
+type Collision int
+
+const (
+	// KeepLast replaces the bound error with the new error. This is the
+	// default.
+	KeepLast Collision = iota
+
+	// KeepFirst keeps the bound error and discards the new error.
+	KeepFirst
+
+	// JoinErrors sets the bound error to errors.Join of both errors.
+	JoinErrors
+)
+
+func (c Collision) String() string {
+	switch c {
+	case KeepLast:
+		return "keep last"
+	case KeepFirst:
+		return "keep first"
+	case JoinErrors:
+		return "join errors"
+	default:
+		return "collision(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// OnCollision sets the Collision behavior for the escape.
+func OnCollision(c Collision) Option {
+	return func(s *Escape) {
+		s.collision = c
+	}
+}
+
+// Sentinel guarantees that a non-nil error returned through the hatch
+// satisfies errors.Is(err, target). The handler it adds runs after all
+// other handlers and leaves the error message unchanged.
+func Sentinel(target error) Option {
+	return func(s *Escape) {
+		s.fns = append([]func(){func() {
+			if !errors.Is(*s.err, target) {
+				*s.err = &sentinelError{*s.err, target}
+			}
+		}}, s.fns...)
+	}
+}
+
+type sentinelError struct {
+	error
+	target error
+}
+
+func (e *sentinelError) Unwrap() []error {
+	return []error{e.error, e.target}
+}
+
+// HandlerTimeout bounds the time the hatch spends calling handler and
+// Finalize functions. They run on another goroutine against a copy of the
+// bound error. If they have not finished after d, the hatch returns the
+// error as it was before any of them ran and leaves them running. Handler
+// functions must therefore replace the error only through the escape, as
+// Wrapf, JoinHandlers and Sentinel do, and not by assigning the variable
+// passed to With.
+func HandlerTimeout(d time.Duration) Option {
+	return func(s *Escape) {
+		s.handlerTimeout = d
+	}
+}
+
+//nolint:gochecknoglobals
+var defaults atomic.Pointer[[]Option]
+
+// SetDefaults sets options applied to every escape created by With, and the
+// constructors built on it, before the options passed to the constructor,
+// which can therefore override them. This lets an application set policy,
+// such as the logger for warnings, in one place. Calling SetDefaults with
+// no options removes the defaults.
+func SetDefaults(opts ...Option) {
+	opts = append([]Option{}, opts...)
+	defaults.Store(&opts)
+}
+
+func hasDefaults() bool {
+	opts := defaults.Load()
+
+	return opts != nil && len(*opts) > 0
+}
+
+func applyDefaults(s *Escape) {
+	if opts := defaults.Load(); opts != nil {
+		for _, opt := range *opts {
+			opt(s)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleOnCollision() {
+	f := func(c handle.Collision) (err error) {
+		escape, hatch := handle.With(&err, handle.OnCollision(c))
+		defer hatch()
+
+		err = errors.New("first")
+
+		escape.On(errors.New("second"))
+
+		return nil
+	}
+
+	fmt.Println(f(handle.KeepLast))
+	fmt.Println(f(handle.KeepFirst))
+	fmt.Println(f(handle.JoinErrors))
+	// Output:
+	// second
+	// first
+	// first
+	// second
+}
+
+func ExampleSentinel() {
+	errStorage := errors.New("storage")
+
+	f := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.Sentinel(errStorage),
+			handle.Wrapf("save"),
+		)
+		defer hatch()
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	err := f()
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, errStorage), errors.Is(err, errFailure))
+	// Output:
+	// save: failure
+	// true true
+}
+
+func ExampleHandlerTimeout() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.HandlerTimeout(10*time.Millisecond),
+			handle.Wrapf("f"),
+			handle.Handlers(func() {
+				// A reporter that never returns.
+				select {}
+			}),
+		)
+		defer hatch()
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	g := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.HandlerTimeout(time.Second),
+			handle.Wrapf("g"),
+		)
+		defer hatch()
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(g())
+	// Output:
+	// failure
+	// g: failure
+}
+
+func ExampleSetDefaults() {
+	handle.SetDefaults(handle.Log(logger()))
+	defer handle.SetDefaults()
+
+	f := func() (err error) {
+		escape, hatch := handle.Error(&err)
+		defer hatch()
+
+		escape.Best(func() error {
+			return errFailure
+		}, "warm cache")
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// level=WARN msg="warm cache" error=failure
+	// <nil>
+}
+
+func ExampleDedupe() {
+	save := func(opts ...handle.Option) (err error) {
+		escape, hatch := handle.With(&err, append(opts, handle.Wrapf("save user"))...)
+		defer hatch()
+
+		_, err = fails("World!")
+		escape.On(err)
+
+		return nil
+	}
+
+	f := func(opts ...handle.Option) (err error) {
+		escape, hatch := handle.With(&err, append(opts, handle.Wrapf("save user"))...)
+		defer hatch()
+
+		escape.On(save(opts...))
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(f(handle.Dedupe()))
+	// Output:
+	// save user: save user: failure
+	// save user: failure
+}
@@ -0,0 +1,104 @@
+package handle
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Origin is one of the errors joined into an aggregate error along with
+// its position and, if known, the location of the call that reported it.
+type Origin struct {
+	Err   error
+	Index int
+	Site  string
+}
+
+// Split returns the errors joined in err, in order, with their origins. An
+// err that is not a joined error is returned as the only Origin.
+//
+// Aggregate errors built by this package have a stable order: escape.On
+// and OnErrs join errors in argument order, Collect in the order On was
+// called, ForRange and Batch in element order. Tests and log parsers can
+// rely on the order of the errors returned by Split and of the lines in
+// the joined message.
+func Split(err error) []Origin {
+	if je, ok := err.(*joinError); ok { //nolint:errorlint
+		origins := make([]Origin, 0, len(je.origins))
+		for i, o := range je.origins {
+			origins = append(origins, Origin{Err: o.err, Index: i, Site: caller(o.pcs[:])})
+		}
+
+		return origins
+	}
+
+	errs := split(err)
+	origins := make([]Origin, 0, len(errs))
+
+	for i, e := range errs {
+		origins = append(origins, Origin{Err: e, Index: i})
+	}
+
+	return origins
+}
+
+// origin is an error along with the call stack at which it was reported.
+type origin struct {
+	err error
+	pcs [callers]uintptr
+}
+
+// originate returns err with the call stack of its caller's caller.
+func originate(err error) origin {
+	o := origin{err: err}
+	if err != nil {
+		runtime.Callers(3, o.pcs[:])
+	}
+
+	return o
+}
+
+// joinOrigins joins the errors in origins like join. When there is more
+// than one non-nil error, the result records their origins for Split while
+// its Unwrap method returns the errors exactly as they were reported.
+func joinOrigins(origins []origin) error {
+	var nonNil []origin
+
+	for _, o := range origins {
+		if o.err != nil {
+			nonNil = append(nonNil, o)
+		}
+	}
+
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0].err
+	}
+
+	return &joinError{nonNil}
+}
+
+// joinError is like the error returned by errors.Join except that it also
+// records where each error was reported.
+type joinError struct {
+	origins []origin
+}
+
+func (e *joinError) Error() string {
+	msgs := make([]string, 0, len(e.origins))
+	for _, o := range e.origins {
+		msgs = append(msgs, o.err.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (e *joinError) Unwrap() []error {
+	errs := make([]error, 0, len(e.origins))
+	for _, o := range e.origins {
+		errs = append(errs, o.err)
+	}
+
+	return errs
+}
@@ -0,0 +1,108 @@
+package handle
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicError is an error converted from a panic by Catch or by a hatch
+// created with the CatchPanics option.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func newPanicError(v interface{}) *PanicError {
+	return &PanicError{Value: v, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+// Catch converts a panic into a *PanicError and sets *err to it. Catch must
+// be deferred:
+//
+//	defer handle.Catch(&err)
+func Catch(err *error) {
+	if r := recover(); r != nil {
+		*err = newPanicError(r)
+	}
+}
+
+// CatchPanics makes the hatch convert panics, other than those triggered by
+// escape.On, into a *PanicError that is handled like any other error.
+func CatchPanics() Option {
+	return func(s *Escape) {
+		s.catch = true
+	}
+}
+
+// PanicValue returns the value passed to panic if err was converted from a
+// panic.
+func PanicValue(err error) (interface{}, bool) {
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		return pe.Value, true
+	}
+
+	return nil, false
+}
+
+// PanicStack returns the stack of the goroutine that panicked if err was
+// converted from a panic.
+func PanicStack(err error) []byte {
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		return pe.Stack
+	}
+
+	return nil
+}
+
+//nolint:gochecknoglobals
+var unhandled atomic.Pointer[func(error, []byte)]
+
+// SetUnhandledHook sets fn to be called by LastResort when an escape without
+// a hatch is about to crash the process. The error passed to fn describes
+// where the escape was created and triggered and stack is the stack of the
+// goroutine that panicked. Passing nil removes the hook.
+func SetUnhandledHook(fn func(err error, stack []byte)) {
+	if fn == nil {
+		unhandled.Store(nil)
+
+		return
+	}
+
+	unhandled.Store(&fn)
+}
+
+// LastResort calls the hook set by SetUnhandledHook if the goroutine is
+// panicking because of an unhandled escape, and then continues panicking.
+// It is a best-effort way for crash reporters to capture these failures and
+// must be deferred at the top of main and of goroutine wrappers:
+//
+//	defer handle.LastResort()
+func LastResort() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if f, ok := r.(failure); ok {
+		if fn := unhandled.Load(); fn != nil {
+			(*fn)(f, debug.Stack())
+		}
+	}
+
+	panic(r)
+}
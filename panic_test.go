@@ -0,0 +1,71 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleCatchPanics() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.CatchPanics(),
+			handle.Wrapf("f"),
+		)
+		defer hatch()
+
+		s, err := works("World!")
+		escape.On(err)
+
+		panic(s)
+	}
+
+	err := f()
+	fmt.Println(err)
+
+	v, ok := handle.PanicValue(err)
+	fmt.Println(v, ok, len(handle.PanicStack(err)) > 0)
+	// Output:
+	// f: panic: Hello, World!
+	// Hello, World! true true
+}
+
+func ExampleCatch() {
+	f := func() (err error) {
+		defer handle.Catch(&err)
+
+		var m map[string]int
+		m["x"] = 1
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: panic: assignment to entry in nil map
+}
+
+func ExampleLastResort() {
+	handle.SetUnhandledHook(func(err error, stack []byte) {
+		fmt.Println(errors.Is(err, errFailure), len(stack) > 0)
+	})
+	defer handle.SetUnhandledHook(nil)
+
+	defer func() {
+		fmt.Println(recover() != nil)
+	}()
+
+	func() {
+		defer handle.LastResort()
+
+		var err error
+
+		// The hatch was never deferred.
+		escape, _ := handle.Error(&err)
+
+		escape.On(errFailure)
+	}()
+	// Output:
+	// true true
+	// true
+}
@@ -0,0 +1,41 @@
+package handle
+
+import "errors"
+
+// OnPartial is like On but attaches partial, the result of the work
+// completed before err, to the error so that callers can recover it with
+// Partial:
+//
+//	n, err := io.Copy(w, r)
+//	escape.OnPartial(err, n)
+func (s *Escape) OnPartial(err error, partial interface{}) {
+	if err != nil {
+		s.On(&partialError{err, partial})
+	}
+}
+
+// Partial returns the partial result attached, by OnPartial, to the first
+// error in err's chain that has one, if that result is a T.
+func Partial[T any](err error) (T, bool) {
+	var (
+		p *partialError
+		v T
+	)
+
+	if !errors.As(err, &p) {
+		return v, false
+	}
+
+	v, ok := p.partial.(T)
+
+	return v, ok
+}
+
+type partialError struct {
+	error
+	partial interface{}
+}
+
+func (e *partialError) Unwrap() error {
+	return e.error
+}
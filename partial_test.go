@@ -0,0 +1,33 @@
+package handle_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing/iotest"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExamplePartial() {
+	f := func(w io.Writer, r io.Reader) (err error) {
+		escape, hatch := handle.Errorf(&err, "copy")
+		defer hatch()
+
+		n, err := io.Copy(w, r)
+		escape.OnPartial(err, n)
+
+		return nil
+	}
+
+	var b strings.Builder
+
+	err := f(&b, iotest.TimeoutReader(strings.NewReader("Hello, World!")))
+	fmt.Println(err)
+
+	n, ok := handle.Partial[int64](err)
+	fmt.Println(n, ok, b.String())
+	// Output:
+	// copy: timeout
+	// 13 true Hello, World!
+}
@@ -0,0 +1,102 @@
+package handle
+
+import "context"
+
+// Pipeline runs stages in their own goroutines connected by channels. An
+// escape in any stage tears down the whole pipeline, by canceling its
+// context, and the error surfaces at Wait:
+//
+//	p, ctx := handle.NewPipeline(ctx)
+//	lines := handle.Source(p, files)
+//	parsed := handle.Stage(p, lines, parse)
+//	handle.Sink(p, parsed, store)
+//	p.Wait(escape)
+type Pipeline struct {
+	ctx context.Context
+	g   *Group
+}
+
+// NewPipeline returns a Pipeline and its context, which is canceled when a
+// stage fails. Each stage's escape is created with opts.
+func NewPipeline(ctx context.Context, opts ...Option) (*Pipeline, context.Context) {
+	g, ctx := NewGroup(ctx, opts...)
+
+	return &Pipeline{ctx, g}, ctx
+}
+
+// Wait waits for every stage to return and triggers an escape with the
+// first error, in time, if any failed.
+func (p *Pipeline) Wait(escape Escaper) {
+	p.g.Wait(escape)
+}
+
+// Source returns a channel on which the pipeline sends each of items.
+func Source[T any](p *Pipeline, items []T) <-chan T {
+	out := make(chan T)
+
+	p.g.Go(func(*Escape) {
+		defer close(out)
+
+		for _, item := range items {
+			if !send(p.ctx, out, item) {
+				return
+			}
+		}
+	})
+
+	return out
+}
+
+// Stage calls fn for each value received from in, in a goroutine of its
+// own, and sends the results on the returned channel. An escape triggered
+// by fn stops the stage and tears down the pipeline.
+func Stage[In, Out any](p *Pipeline, in <-chan In, fn func(*Escape, In) Out) <-chan Out {
+	out := make(chan Out)
+
+	p.g.Go(func(escape *Escape) {
+		defer close(out)
+
+		for {
+			v, ok := receive(p.ctx, in)
+			if !ok || !send(p.ctx, out, fn(escape, v)) {
+				return
+			}
+		}
+	})
+
+	return out
+}
+
+// Sink calls fn for each value received from in in a goroutine of its own.
+func Sink[T any](p *Pipeline, in <-chan T, fn func(*Escape, T)) {
+	p.g.Go(func(escape *Escape) {
+		for {
+			v, ok := receive(p.ctx, in)
+			if !ok {
+				return
+			}
+
+			fn(escape, v)
+		}
+	})
+}
+
+func receive[T any](ctx context.Context, in <-chan T) (T, bool) {
+	select {
+	case v, ok := <-in:
+		return v, ok
+	case <-ctx.Done():
+		var zero T
+
+		return zero, false
+	}
+}
+
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,42 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExamplePipeline() {
+	f := func(inputs ...string) (err error) {
+		escape, hatch := handle.Errorf(&err, "sum")
+		defer hatch()
+
+		p, _ := handle.NewPipeline(context.Background())
+
+		strs := handle.Source(p, inputs)
+		ints := handle.Stage(p, strs, func(escape *handle.Escape, s string) int {
+			return handle.Check(strconv.Atoi(s))(escape)
+		})
+
+		sum := 0
+
+		handle.Sink(p, ints, func(_ *handle.Escape, n int) {
+			sum += n
+		})
+
+		p.Wait(escape)
+
+		fmt.Println(sum)
+
+		return nil
+	}
+
+	fmt.Println(f("1", "2", "3"))
+	fmt.Println(f("1", "two", "3"))
+	// Output:
+	// 6
+	// <nil>
+	// sum: strconv.Atoi: parsing "two": invalid syntax
+}
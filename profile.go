@@ -0,0 +1,142 @@
+package handle
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+//nolint:gochecknoglobals
+var (
+	profiling atomic.Bool
+	profiles  = &chainProfiles{ops: map[string]*ChainProfile{}}
+)
+
+// Profile turns error chain profiling on or off. While it is on, the hatch
+// of an escape named by the Op option measures each error it returns: the
+// number of errors in the chain, each roughly one allocation, and their
+// approximate size in bytes, including messages and stacks. Profiles then
+// reports the operations with the largest chains so that teams can decide
+// which features, such as stacks, to enable on hot paths. Profiling adds
+// work to every failure, so it is off by default.
+func Profile(on bool) {
+	profiling.Store(on)
+}
+
+// ChainProfile summarizes the error chains returned by an operation.
+type ChainProfile struct {
+	Op         string
+	Count      int64
+	MaxLinks   int
+	MaxBytes   int
+	TotalBytes int64
+}
+
+// Profiles returns the profiles of the n operations whose error chains
+// have been largest, largest first. If n is negative, all are returned.
+func Profiles(n int) []ChainProfile {
+	return profiles.worst(n)
+}
+
+type chainProfiles struct {
+	mu  sync.Mutex
+	ops map[string]*ChainProfile
+}
+
+func (c *chainProfiles) record(op string, err error) {
+	links, bytes := measure(err)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.ops[op]
+	if p == nil {
+		p = &ChainProfile{Op: op}
+		c.ops[op] = p
+	}
+
+	p.Count++
+	p.MaxLinks = max(p.MaxLinks, links)
+	p.MaxBytes = max(p.MaxBytes, bytes)
+	p.TotalBytes += int64(bytes)
+}
+
+func (c *chainProfiles) worst(n int) []ChainProfile {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ps := make([]ChainProfile, 0, len(c.ops))
+	for _, p := range c.ops {
+		ps = append(ps, *p)
+	}
+
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].MaxBytes != ps[j].MaxBytes {
+			return ps[i].MaxBytes > ps[j].MaxBytes
+		}
+
+		return ps[i].Op < ps[j].Op
+	})
+
+	if n >= 0 && n < len(ps) {
+		ps = ps[:n]
+	}
+
+	return ps
+}
+
+// measure returns the number of errors in err's tree and an estimate of
+// the bytes they occupy.
+func measure(err error) (links, bytes int) {
+	if err == nil {
+		return 0, 0
+	}
+
+	links, bytes = 1, size(reflect.ValueOf(err))
+
+	var children []error
+
+	switch u := err.(type) { //nolint:errorlint
+	case interface{ Unwrap() []error }:
+		children = u.Unwrap()
+	default:
+		children = []error{errors.Unwrap(err)}
+	}
+
+	for _, child := range children {
+		l, b := measure(child)
+		links += l
+		bytes += b
+	}
+
+	return links, bytes
+}
+
+// size estimates the bytes occupied by v, following a pointer and counting
+// the contents of the strings and byte slices directly in a struct.
+func size(v reflect.Value) int {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return 0
+		}
+
+		v = v.Elem()
+	}
+
+	n := int(v.Type().Size())
+
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			switch f := v.Field(i); {
+			case f.Kind() == reflect.String:
+				n += f.Len()
+			case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.Uint8:
+				n += f.Cap()
+			}
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,42 @@
+package handle_test
+
+import (
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleProfiles() {
+	handle.Profile(true)
+	defer handle.Profile(false)
+
+	small := func() (err error) {
+		escape, hatch := handle.With(&err, handle.Op("profile.small"))
+		defer hatch()
+
+		escape.On(errFailure)
+
+		return nil
+	}
+
+	large := func() (err error) {
+		escape, hatch := handle.With(&err, handle.Op("profile.large"), handle.Wrapf("large"))
+		defer hatch()
+
+		escape.Bug(errFailure, "invariant")
+
+		return nil
+	}
+
+	_ = small()
+	_ = large()
+
+	for _, p := range handle.Profiles(-1) {
+		if p.Op == "profile.small" || p.Op == "profile.large" {
+			fmt.Println(p.Op, p.Count, p.MaxLinks)
+		}
+	}
+	// Output:
+	// profile.large 1 4
+	// profile.small 1 1
+}
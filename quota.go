@@ -0,0 +1,58 @@
+package handle
+
+import "time"
+
+// WithRetryAfter returns err, of the ResourceExhausted kind, carrying the
+// delay d after which the operation may be retried. Clients use it to
+// capture retry-after information from fields of the errors returned by
+// the services they call. It returns nil if err is nil.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return &retryAfterError{Tag(err, ResourceExhausted), d}
+}
+
+// RetryAfter returns the delay after which the operation that failed with
+// err may be retried. The delay is found in the first error in err's chain
+// with a RetryAfter method or a *StatusError with a Retry-After header.
+// Retry waits at least this long before retrying.
+func RetryAfter(err error) (time.Duration, bool) {
+	switch e := err.(type) { //nolint:errorlint
+	case nil:
+		return 0, false
+	case *StatusError:
+		if e.RetryAfter > 0 {
+			return e.RetryAfter, true
+		}
+	case interface{ RetryAfter() time.Duration }:
+		return e.RetryAfter(), true
+	}
+
+	switch u := err.(type) { //nolint:errorlint
+	case interface{ Unwrap() error }:
+		return RetryAfter(u.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range u.Unwrap() {
+			if d, ok := RetryAfter(err); ok {
+				return d, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+type retryAfterError struct {
+	error
+	after time.Duration
+}
+
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.after
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.error
+}
@@ -0,0 +1,71 @@
+package handle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleRetryAfter() {
+	check := func(resp *http.Response) (err error) {
+		escape, hatch := handle.Errorf(&err, "list")
+		defer hatch()
+
+		escape.OnStatus(resp, http.StatusOK)
+
+		return nil
+	}
+
+	err := check(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+	})
+
+	fmt.Println(err)
+	fmt.Println(handle.KindOf(err), handle.Transient(err))
+	fmt.Println(handle.RetryAfter(err))
+	// Output:
+	// list: 429 Too Many Requests
+	// resource exhausted true
+	// 30s true
+}
+
+func ExampleRetryAfter_first() {
+	resp := &handle.StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}
+
+	fmt.Println(handle.RetryAfter(handle.WithRetryAfter(resp, time.Second)))
+	fmt.Println(handle.RetryAfter(fmt.Errorf("put: %w", resp)))
+	fmt.Println(handle.RetryAfter(errors.New("failure")))
+	// Output:
+	// 1s true
+	// 1m0s true
+	// 0s false
+}
+
+func ExampleWithRetryAfter() {
+	start := time.Now()
+	calls := 0
+
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "put")
+		defer hatch()
+
+		handle.Retry(context.Background(), escape, 2, 0, func(context.Context) error {
+			calls++
+
+			return handle.WithRetryAfter(errors.New("quota exceeded"), 10*time.Millisecond)
+		})
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(calls, time.Since(start) >= 10*time.Millisecond)
+	// Output:
+	// put: 2 attempts failed: quota exceeded
+	// 2 true
+}
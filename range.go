@@ -0,0 +1,102 @@
+package handle
+
+import "strconv"
+
+// ItemError annotates an error returned while processing an element of a
+// slice with the element's index and, if known, its key.
+type ItemError struct {
+	Index int
+	Key   string
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	s := "item " + strconv.Itoa(e.Index)
+	if e.Key != "" {
+		s += " (" + e.Key + ")"
+	}
+
+	return s + ": " + e.Err.Error()
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// RangeOption configures ForRange and the helpers built on it.
+type RangeOption func(*rangeConfig)
+
+type rangeConfig struct {
+	accumulate bool
+	key        func(interface{}) string
+}
+
+// Accumulate processes every element before triggering an escape with the
+// errors for the elements that failed joined. By default the escape is
+// triggered by the first failure.
+func Accumulate() RangeOption {
+	return func(c *rangeConfig) {
+		c.accumulate = true
+	}
+}
+
+// WithKey sets the function used to derive the key recorded in an
+// ItemError from the element that failed. It must be called with the
+// element type of the slice.
+func WithKey[T any](key func(T) string) RangeOption {
+	return func(c *rangeConfig) {
+		c.key = func(v interface{}) string {
+			return key(v.(T)) //nolint:forcetypeassert
+		}
+	}
+}
+
+// ForRange calls fn for each element of items. An error returned by fn is
+// annotated with the element's index, and key if configured, in an
+// *ItemError and, depending on opts, either triggers an escape immediately
+// or is accumulated:
+//
+//	handle.ForRange(escape, users, func(i int, u User) error {
+//	    return store.Save(ctx, u)
+//	}, handle.WithKey(func(u User) string { return u.ID }))
+func ForRange[T any](escape Escaper, items []T, fn func(i int, item T) error, opts ...RangeOption) {
+	c := newRangeConfig(opts)
+
+	var errs []error
+
+	for i, item := range items {
+		if err := c.item(i, item, fn(i, item)); err != nil {
+			if !c.accumulate {
+				escape.On(err)
+
+				continue
+			}
+
+			errs = append(errs, err)
+		}
+	}
+
+	escape.On(errs...)
+}
+
+func newRangeConfig(opts []RangeOption) *rangeConfig {
+	c := &rangeConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *rangeConfig) item(i int, v interface{}, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &ItemError{Index: i, Err: err}
+	if c.key != nil {
+		e.Key = c.key(v)
+	}
+
+	return e
+}
@@ -0,0 +1,53 @@
+package handle_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleForRange() {
+	type user struct {
+		id  string
+		age string
+	}
+
+	users := []user{{"ann", "41"}, {"bob", "x"}, {"cy", "?"}}
+
+	f := func(opts ...handle.RangeOption) (err error) {
+		escape, hatch := handle.Errorf(&err, "load users")
+		defer hatch()
+
+		opts = append(opts, handle.WithKey(func(u user) string { return u.id }))
+
+		handle.ForRange(escape, users, func(i int, u user) error {
+			_, err := strconv.Atoi(u.age)
+
+			return err
+		}, opts...)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(f(handle.Accumulate()))
+	// Output:
+	// load users: item 1 (bob): strconv.Atoi: parsing "x": invalid syntax
+	// load users: item 1 (bob): strconv.Atoi: parsing "x": invalid syntax
+	// item 2 (cy): strconv.Atoi: parsing "?": invalid syntax
+}
+
+func ExampleForRange_collect() {
+	// With an Escaper that does not panic, each failure is reported once.
+	var c handle.Collect
+
+	handle.ForRange(&c, []string{"1", "x"}, func(i int, s string) error {
+		_, err := strconv.Atoi(s)
+
+		return err
+	})
+
+	fmt.Println(c.Err())
+	// Output: item 1: strconv.Atoi: parsing "x": invalid syntax
+}
@@ -0,0 +1,136 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ErrRetryBudget is wrapped by the error with which Retry triggers an
+// escape when the retry budget carried by its context is exhausted.
+var ErrRetryBudget = errors.New("retry budget exhausted")
+
+type retryBudgetKey struct{}
+
+// WithRetryBudget returns a context carrying a budget of n retries shared
+// by every Retry using the context, or a context derived from it. This
+// bounds the total number of retries made while serving a request, however
+// many layers retry, preventing multiplicative retry storms. If ctx already
+// carries a budget, ctx is returned unchanged so that inner layers cannot
+// reset the budget of outer ones.
+func WithRetryBudget(ctx context.Context, n int) context.Context {
+	if _, ok := ctx.Value(retryBudgetKey{}).(*atomic.Int64); ok {
+		return ctx
+	}
+
+	budget := &atomic.Int64{}
+	budget.Store(int64(n))
+
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// RetryBudget returns the number of retries remaining in the budget
+// carried by ctx and whether ctx carries a budget.
+func RetryBudget(ctx context.Context) (int, bool) {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*atomic.Int64)
+	if !ok {
+		return 0, false
+	}
+
+	return int(max(budget.Load(), 0)), true
+}
+
+// RetryError summarizes the failures of an operation attempted more than
+// once by Retry. Rather than nesting a layer per attempt, it keeps the
+// first and last errors. They unwrap last first, matching the message, so
+// that errors.As finds the most recent failure.
+type RetryError struct {
+	Attempts int
+	First    error
+	Last     error
+}
+
+func (e *RetryError) Error() string {
+	s := strconv.Itoa(e.Attempts) + " attempts failed"
+
+	last := e.Last.Error()
+	if first := e.First.Error(); first != last {
+		return s + ", last: " + last + "; first: " + first
+	}
+
+	return s + ": " + last
+}
+
+func (e *RetryError) Unwrap() []error {
+	return []error{e.Last, e.First}
+}
+
+// Retry calls fn until it succeeds, it returns an error that is not
+// Transient, it has been called attempts times or ctx is done. The delay
+// before each retry starts at backoff and doubles, but is never less than
+// the delay reported by RetryAfter for the error. Each retry spends one
+// from the retry budget carried by ctx, if any. If fn does not succeed,
+// Retry triggers an escape with its error or, if fn was called more than
+// once, a *RetryError:
+//
+//	handle.Retry(ctx, escape, 3, 100*time.Millisecond, func(ctx context.Context) error {
+//	    return client.Put(ctx, key, value)
+//	})
+func Retry(ctx context.Context, escape Escaper, attempts int, backoff time.Duration, fn func(context.Context) error) {
+	var first error
+
+	for attempt := 1; ; attempt++ {
+		last := fn(ctx)
+		if last == nil {
+			return
+		}
+
+		err := last
+		if first == nil {
+			first = last
+		} else {
+			err = &RetryError{attempt, first, last}
+		}
+
+		if attempt >= attempts || !Transient(last) {
+			escape.On(err)
+
+			return
+		}
+
+		if !spend(ctx) {
+			escape.On(fmt.Errorf("%w: %w", ErrRetryBudget, err))
+
+			return
+		}
+
+		delay := backoff
+		if d, ok := RetryAfter(last); ok && d > delay {
+			delay = d
+		}
+
+		t := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			escape.On(err)
+
+			return
+		case <-t.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+// spend takes one retry from the budget carried by ctx. It reports whether
+// the retry may go ahead.
+func spend(ctx context.Context) bool {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*atomic.Int64)
+
+	return !ok || budget.Add(-1) >= 0
+}
@@ -0,0 +1,57 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleWithRetryBudget() {
+	calls := 0
+
+	put := func(ctx context.Context) (err error) {
+		escape, hatch := handle.Errorf(&err, "put")
+		defer hatch()
+
+		handle.Retry(ctx, escape, 3, 0, func(context.Context) error {
+			calls++
+
+			return context.DeadlineExceeded
+		})
+
+		return nil
+	}
+
+	// Two calls that may each retry twice share a budget of three retries.
+	ctx := handle.WithRetryBudget(context.Background(), 3)
+
+	fmt.Println(put(ctx))
+	fmt.Println(put(ctx))
+	fmt.Println(calls)
+	// Output:
+	// put: 3 attempts failed: context deadline exceeded
+	// put: retry budget exhausted: 2 attempts failed: context deadline exceeded
+	// 5
+}
+
+func ExampleRetryError() {
+	errs := []error{context.DeadlineExceeded, context.DeadlineExceeded, errFailure}
+
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "get")
+		defer hatch()
+
+		handle.Retry(context.Background(), escape, 5, 0, func(context.Context) error {
+			e := errs[0]
+			errs = errs[1:]
+
+			return e
+		})
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: get: 3 attempts failed, last: failure; first: context deadline exceeded
+}
@@ -0,0 +1,46 @@
+package handle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Shutdown calls closers concurrently with a context that expires after
+// timeout. It triggers an escape with the joined errors of the closers, in
+// the order the closers were passed, or, if they have not all returned by
+// the deadline, with an error matching context.DeadlineExceeded. Closers
+// still running at the deadline are not waited for.
+func Shutdown(escape Escaper, timeout time.Duration, closers ...func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errs := make([]error, len(closers))
+
+	var wg sync.WaitGroup
+
+	for i, closer := range closers {
+		wg.Add(1)
+
+		go func(i int, closer func(context.Context) error) {
+			defer wg.Done()
+
+			errs[i] = closer(ctx)
+		}(i, closer)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		escape.On(errs...)
+	case <-ctx.Done():
+		escape.On(fmt.Errorf("shutdown exceeded %s: %w", timeout, ctx.Err()))
+	}
+}
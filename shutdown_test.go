@@ -0,0 +1,41 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleShutdown() {
+	quick := func(err error) func(context.Context) error {
+		return func(context.Context) error {
+			return err
+		}
+	}
+
+	stuck := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Millisecond)
+
+		return ctx.Err()
+	}
+
+	f := func(closers ...func(context.Context) error) (err error) {
+		escape, hatch := handle.Errorf(&err, "stop server")
+		defer hatch()
+
+		handle.Shutdown(escape, 10*time.Millisecond, closers...)
+
+		return nil
+	}
+
+	fmt.Println(f(quick(nil), quick(nil)))
+	fmt.Println(f(quick(nil), quick(errFailure)))
+	fmt.Println(f(quick(nil), stuck))
+	// Output:
+	// <nil>
+	// stop server: failure
+	// stop server: shutdown exceeded 10ms: context deadline exceeded
+}
@@ -0,0 +1,205 @@
+package handle
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Op names the operation performed by the function in which the hatch is
+// deferred. Each failure returned through the hatch is counted in the
+// process-wide stats registry under its Fingerprint and, if enabled by
+// Profile, profiled.
+func Op(name string) Option {
+	return func(s *Escape) {
+		s.op = name
+
+		s.exit = append(s.exit, func() {
+			if err := s.Err(); err != nil {
+				stats.fail(name, Fingerprint(name, err))
+
+				if profiling.Load() {
+					profiles.record(name, err)
+				}
+			}
+		})
+	}
+}
+
+// Fingerprint identifies a class of failure for the operation op. It is
+// made up of op, the type of the innermost error in err's chain and, if err
+// has one, its Kind. Messages, which often contain variable data, are not
+// included so that fingerprints remain stable from one occurrence, and one
+// deploy, to the next.
+func Fingerprint(op string, err error) string {
+	root := err
+	for next := errors.Unwrap(root); next != nil; next = errors.Unwrap(root) {
+		root = next
+	}
+
+	fp := op + " " + reflect.TypeOf(root).String()
+	if k := KindOf(err); k != "" {
+		fp += " (" + string(k) + ")"
+	}
+
+	return fp
+}
+
+// Snapshot maps fingerprints to the number of failures counted.
+type Snapshot map[string]int64
+
+// Stats returns a snapshot of the stats registry.
+func Stats() Snapshot {
+	return stats.snapshot()
+}
+
+// ServeStats is an http.HandlerFunc that writes a snapshot of the stats
+// registry as a JSON object so that it can be scraped and compared, by
+// DiffStats, before and after a deploy.
+func ServeStats(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(Stats())
+}
+
+// StatsDiff is the change in the count for a fingerprint between two
+// snapshots.
+type StatsDiff struct {
+	Fingerprint string
+	Before      int64
+	After       int64
+}
+
+// New reports whether the fingerprint is absent from the earlier snapshot.
+func (d StatsDiff) New() bool {
+	return d.Before == 0
+}
+
+// DiffStats compares the snapshots a and b and returns, sorted by
+// fingerprint, a StatsDiff for each fingerprint whose count differs.
+func DiffStats(a, b Snapshot) []StatsDiff {
+	var diffs []StatsDiff
+
+	for fp, n := range b {
+		if a[fp] != n {
+			diffs = append(diffs, StatsDiff{fp, a[fp], n})
+		}
+	}
+
+	for fp, n := range a {
+		if _, ok := b[fp]; !ok {
+			diffs = append(diffs, StatsDiff{fp, n, 0})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Fingerprint < diffs[j].Fingerprint
+	})
+
+	return diffs
+}
+
+// Failure is a failure of an operation recorded in the stats registry.
+type Failure struct {
+	Time        time.Time
+	Fingerprint string
+}
+
+// RecentFailures returns the failures of the operation op, named by the Op
+// option, that occurred within the last d, oldest first. Only the most
+// recent failures of each operation are kept. This lets code adapt, for
+// example by switching regions or shedding load, based on failures that
+// handle has already seen:
+//
+//	if len(handle.RecentFailures("s3.put", time.Minute)) > 10 {
+//	    region = fallback
+//	}
+func RecentFailures(op string, d time.Duration) []Failure {
+	return stats.recent(op, time.Now().Add(-d))
+}
+
+// historySize is the number of failures kept for each operation.
+const historySize = 64
+
+type registryStats struct {
+	mu      sync.Mutex
+	counts  map[string]int64
+	history map[string]*history
+}
+
+// history is a ring buffer of the most recent failures of an operation.
+type history struct {
+	failures [historySize]Failure
+	next     int
+	full     bool
+}
+
+//nolint:gochecknoglobals
+var stats = &registryStats{counts: map[string]int64{}, history: map[string]*history{}}
+
+func (r *registryStats) fail(op, fp string) {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counts[fp]++
+
+	h := r.history[op]
+	if h == nil {
+		h = &history{}
+		r.history[op] = h
+	}
+
+	h.failures[h.next] = Failure{now, fp}
+	h.next = (h.next + 1) % historySize
+	h.full = h.full || h.next == 0
+}
+
+func (r *registryStats) recent(op string, since time.Time) []Failure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h := r.history[op]
+	if h == nil {
+		return nil
+	}
+
+	ordered := h.failures[:h.next]
+	if h.full {
+		ordered = append(append([]Failure{}, h.failures[h.next:]...), ordered...)
+	}
+
+	var failures []Failure
+
+	for _, f := range ordered {
+		if f.Time.After(since) {
+			failures = append(failures, f)
+		}
+	}
+
+	return failures
+}
+
+func (r *registryStats) record(fp string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counts[fp]++
+}
+
+func (r *registryStats) snapshot() Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make(Snapshot, len(r.counts))
+	for fp, n := range r.counts {
+		s[fp] = n
+	}
+
+	return s
+}
@@ -0,0 +1,57 @@
+package handle_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleDiffStats() {
+	decode := func(data string) (err error) {
+		escape, hatch := handle.With(&err, handle.Op("config.decode"))
+		defer hatch()
+
+		var v interface{}
+		escape.On(json.Unmarshal([]byte(data), &v))
+
+		return nil
+	}
+
+	before := handle.Stats()
+
+	_ = decode("{")
+	_ = decode("[")
+	_ = decode("{}")
+
+	for _, d := range handle.DiffStats(before, handle.Stats()) {
+		fmt.Println(d.Fingerprint, d.After-d.Before, d.New())
+	}
+	// Output: config.decode *json.SyntaxError 2 true
+}
+
+func ExampleRecentFailures() {
+	put := func(ok bool) (err error) {
+		escape, hatch := handle.With(&err, handle.Op("example.put"))
+		defer hatch()
+
+		if !ok {
+			_, err = fails("World!")
+			escape.On(err)
+		}
+
+		return nil
+	}
+
+	for _, ok := range []bool{false, true, false} {
+		_ = put(ok)
+	}
+
+	for _, f := range handle.RecentFailures("example.put", time.Minute) {
+		fmt.Println(f.Fingerprint)
+	}
+	// Output:
+	// example.put *errors.errorString
+	// example.put *errors.errorString
+}
@@ -0,0 +1,107 @@
+// Package steps runs ordered, named, idempotent steps, such as migrations
+// or provisioning actions, with handle. Each step gets its own escape, a
+// failure stops the run with the step's name wrapped around the error and
+// steps already applied, according to a pluggable Store, are skipped.
+package steps
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+// Step is a named unit of work. Run must be idempotent: a step that failed
+// after making changes, or whose completion was not recorded, runs again.
+type Step struct {
+	Name string
+	Run  func(ctx context.Context, escape *handle.Escape)
+}
+
+// Store records which steps have been applied.
+type Store interface {
+	Applied(ctx context.Context, name string) (bool, error)
+	MarkApplied(ctx context.Context, name string) error
+}
+
+// Error reports the failure of a step.
+type Error struct {
+	Step string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return "step " + e.Step + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Run runs each of steps, in order, that store does not record as
+// applied, and records each step that succeeds. The first failure triggers
+// an escape with an *Error. Each step's escape is created with opts.
+func Run(ctx context.Context, escape handle.Escaper, store Store, steps []Step, opts ...handle.Option) {
+	seen := map[string]bool{}
+
+	for _, step := range steps {
+		if seen[step.Name] {
+			escape.On(handle.Tag(fmt.Errorf("duplicate step %q", step.Name), handle.InvalidArgument)) //nolint:goerr113
+		}
+
+		seen[step.Name] = true
+	}
+
+	for _, step := range steps {
+		escape.On(run(ctx, store, step, opts))
+	}
+}
+
+func run(ctx context.Context, store Store, step Step, opts []handle.Option) (err error) {
+	escape, hatch := handle.With(&err, append(opts[:len(opts):len(opts)], handle.Handlers(func() {
+		err = &Error{step.Name, err}
+	}))...)
+	defer hatch()
+
+	if handle.Check(store.Applied(ctx, step.Name))(escape) {
+		return nil
+	}
+
+	escape.OnCtx(ctx)
+
+	step.Run(ctx, escape)
+
+	return store.MarkApplied(ctx, step.Name)
+}
+
+// MemoryStore is a Store that keeps its records in memory. The zero value
+// is ready to use and a MemoryStore is safe for concurrent use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	applied map[string]bool
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// Applied reports whether the step called name has been applied.
+func (m *MemoryStore) Applied(_ context.Context, name string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.applied[name], nil
+}
+
+// MarkApplied records that the step called name has been applied.
+func (m *MemoryStore) MarkApplied(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.applied == nil {
+		m.applied = map[string]bool{}
+	}
+
+	m.applied[name] = true
+
+	return nil
+}
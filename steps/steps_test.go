@@ -0,0 +1,50 @@
+package steps_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+	"github.com/michaelmacinnis/handle/steps"
+)
+
+func Example() {
+	var store steps.MemoryStore
+
+	ready := false
+
+	migrations := []steps.Step{
+		{Name: "create users", Run: func(context.Context, *handle.Escape) {
+			fmt.Println("creating users")
+		}},
+		{Name: "add email", Run: func(_ context.Context, escape *handle.Escape) {
+			fmt.Println("adding email")
+
+			if !ready {
+				escape.On(errors.New("lock timeout"))
+			}
+		}},
+	}
+
+	migrate := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "migrate")
+		defer hatch()
+
+		steps.Run(context.Background(), escape, &store, migrations)
+
+		return nil
+	}
+
+	fmt.Println(migrate())
+
+	ready = true
+
+	fmt.Println(migrate())
+	// Output:
+	// creating users
+	// adding email
+	// migrate: step add email: lock timeout
+	// adding email
+	// <nil>
+}
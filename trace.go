@@ -0,0 +1,69 @@
+package handle
+
+import (
+	"context"
+	"log/slog"
+	"runtime/trace"
+	"time"
+)
+
+// Logger is the logging interface used by handle. It is satisfied by
+// *slog.Logger.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, args ...interface{})
+}
+
+// Trace logs entry to the function name, with the key-value pairs in args,
+// and returns a function that logs the exit from name along with the time
+// taken and the outcome. The outcome is the error bound to escape, so the
+// returned function must run after the hatch. Defer the call to Trace
+// before the hatch:
+//
+//	escape, hatch := handle.Errorf(&err, "copy %s %s", src, dst)
+//	defer handle.Trace(escape, logger, "CopyFile", "src", src)()
+//	defer hatch()
+func Trace(escape Escaper, l Logger, name string, args ...interface{}) func() {
+	ctx := context.Background()
+	start := time.Now()
+
+	l.Log(ctx, slog.LevelDebug, "enter", append([]interface{}{"func", name}, args...)...)
+
+	return func() {
+		attrs := append([]interface{}{"func", name, "duration", time.Since(start)}, args...)
+
+		if err := escape.Err(); err != nil {
+			level := slog.LevelError
+			if leveler, ok := escape.(interface {
+				level(error, slog.Level) slog.Level
+			}); ok {
+				level = leveler.level(err, level)
+			}
+
+			l.Log(ctx, level, "exit", append(attrs, "error", err)...)
+
+			return
+		}
+
+		l.Log(ctx, slog.LevelDebug, "exit", attrs...)
+	}
+}
+
+// Region opens a runtime/trace region called name for the function in which
+// the hatch is deferred. The hatch logs the outcome, "ok" or the error, to
+// the region before ending it so that go tool trace shows which regions
+// ended in escapes.
+func Region(ctx context.Context, name string) Option {
+	return func(s *Escape) {
+		r := trace.StartRegion(ctx, name)
+
+		s.exit = append(s.exit, func() {
+			outcome := "ok"
+			if err := s.Err(); err != nil {
+				outcome = err.Error()
+			}
+
+			trace.Log(ctx, name, outcome)
+			r.End()
+		})
+	}
+}
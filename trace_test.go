@@ -0,0 +1,106 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime/trace"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+// logger returns a logger that writes to stdout without timestamps or
+// durations so that its output is stable enough for examples.
+func logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+func ExampleTrace() {
+	l := logger()
+
+	f := func(name string) (err error) {
+		escape, hatch := handle.Errorf(&err, "f(%s)", name)
+		defer handle.Trace(escape, l, "f", "name", name)()
+		defer hatch()
+
+		_, err = works(name)
+		escape.On(err)
+
+		_, err = fails(name)
+		escape.On(err)
+
+		return nil
+	}
+
+	_ = f("World!")
+	// Output:
+	// level=DEBUG msg=enter func=f name=World!
+	// level=ERROR msg=exit func=f name=World! error="f(World!): failure"
+}
+
+func ExampleRegion() {
+	f := func(ctx context.Context, name string) (err error) {
+		escape, hatch := handle.With(&err,
+			handle.Region(ctx, "f"),
+			handle.Wrapf("f(%s)", name),
+		)
+		defer hatch()
+
+		_, err = fails(name)
+		escape.On(err)
+
+		return nil
+	}
+
+	// With tracing enabled, the region for f ends with the log message
+	// "f(World!): failure".
+	_ = trace.Start(io.Discard)
+	defer trace.Stop()
+
+	fmt.Println(f(context.Background(), "World!"))
+	// Output: f(World!): failure
+}
+
+// discard returns a logger that discards its output.
+func discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func ExampleLevel() {
+	l := logger()
+
+	f := func(err error) (rerr error) {
+		escape, hatch := handle.With(&rerr,
+			handle.Level(slog.LevelDebug, context.Canceled),
+			handle.Level(slog.LevelInfo, handle.InvalidArgument),
+		)
+		defer handle.Trace(escape, l, "f")()
+		defer hatch()
+
+		escape.On(err)
+
+		return nil
+	}
+
+	_ = f(context.Canceled)
+	_ = f(handle.Tag(errFailure, handle.InvalidArgument))
+	_ = f(errFailure)
+	// Output:
+	// level=DEBUG msg=enter func=f
+	// level=DEBUG msg=exit func=f error="context canceled"
+	// level=DEBUG msg=enter func=f
+	// level=INFO msg=exit func=f error=failure
+	// level=DEBUG msg=enter func=f
+	// level=ERROR msg=exit func=f error=failure
+}
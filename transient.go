@@ -0,0 +1,44 @@
+package handle
+
+import (
+	"context"
+	"errors"
+)
+
+// Transient reports whether err is classified as temporary: it matches
+// context.DeadlineExceeded or ResourceExhausted or an error in its chain has
+// a Timeout or Temporary method that returns true.
+func Transient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ResourceExhausted) {
+		return true
+	}
+
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+
+	var temporary interface{ Temporary() bool }
+
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+// OnPermanent triggers an escape if err is non-nil and not Transient. It
+// returns true if err is transient so that retry loops can stay flat:
+//
+//	for escape.OnPermanent(call()) {
+//	    // Back off.
+//	}
+func (s *Escape) OnPermanent(err error) bool {
+	if Transient(err) {
+		return true
+	}
+
+	s.On(err)
+
+	return false
+}
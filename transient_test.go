@@ -0,0 +1,43 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_OnPermanent() {
+	results := []error{context.DeadlineExceeded, context.DeadlineExceeded, nil}
+
+	f := func() (err error) {
+		escape, hatch := handle.Errorf(&err, "call")
+		defer hatch()
+
+		attempt := 0
+		call := func() error {
+			attempt++
+			return results[attempt-1]
+		}
+
+		for escape.OnPermanent(call()) {
+			fmt.Println("retrying")
+		}
+
+		fmt.Println("attempts:", attempt)
+
+		return nil
+	}
+
+	fmt.Println(f())
+
+	results = []error{context.DeadlineExceeded, errFailure}
+	fmt.Println(f())
+	// Output:
+	// retrying
+	// retrying
+	// attempts: 3
+	// <nil>
+	// retrying
+	// call: failure
+}
@@ -0,0 +1,49 @@
+package handle
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// MaxLen caps the length of the message of a returned error at n bytes.
+// Longer messages are cut and marked as truncated. The full error remains
+// available through errors.Unwrap or as the Err field of *TruncatedError.
+// The cap applies after all handler functions have run. If n is not
+// positive, messages are not capped.
+func MaxLen(n int) Option {
+	return func(s *Escape) {
+		if n <= 0 {
+			return
+		}
+
+		s.fns = append([]func(){func() {
+			if len((*s.err).Error()) > n {
+				*s.err = &TruncatedError{Err: *s.err, Limit: n}
+			}
+		}}, s.fns...)
+	}
+}
+
+// TruncatedError is an error whose message has been truncated.
+type TruncatedError struct {
+	Err   error
+	Limit int
+}
+
+func (e *TruncatedError) Error() string {
+	msg := e.Err.Error()
+	if len(msg) <= e.Limit {
+		return msg
+	}
+
+	cut := max(e.Limit, 0)
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+
+	return msg[:cut] + "... (" + strconv.Itoa(len(msg)-cut) + " bytes truncated)"
+}
+
+func (e *TruncatedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,52 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleMaxLen() {
+	f := func(query string) (err error) {
+		escape, hatch := handle.With(&err,
+			handle.MaxLen(32),
+			handle.Wrapf("query %q", query),
+		)
+		defer hatch()
+
+		_, err = fails(query)
+		escape.On(err)
+
+		return nil
+	}
+
+	err := f("SELECT " + strings.Repeat("x, ", 100) + "y FROM t")
+	fmt.Println(err)
+
+	var te *handle.TruncatedError
+	fmt.Println(errors.As(err, &te), len(te.Err.Error()))
+	// Output:
+	// query "SELECT x, x, x, x, x, x, ... (300 bytes truncated)
+	// true 332
+}
+
+func ExampleMaxLen_notPositive() {
+	f := func(n int) (err error) {
+		escape, hatch := handle.With(&err, handle.MaxLen(n))
+		defer hatch()
+
+		escape.On(errors.New("message"))
+
+		return nil
+	}
+
+	fmt.Println(f(0))
+	fmt.Println(f(-1))
+	fmt.Println(&handle.TruncatedError{Err: errors.New("message"), Limit: -1})
+	// Output:
+	// message
+	// message
+	// ... (7 bytes truncated)
+}
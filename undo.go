@@ -0,0 +1,66 @@
+package handle
+
+// Undo registers fn to be called by the hatch if the function returns an
+// error. Undo functions are called in reverse order of registration, before
+// cleanups and handler functions.
+func (s *Escape) Undo(fn func()) {
+	s.undo = append(s.undo, fn)
+}
+
+func (s *Escape) rollback() {
+	if *s.err != nil {
+		for i := len(s.undo) - 1; i >= 0; i-- {
+			s.undo[i]()
+		}
+	}
+
+	s.undo = nil
+}
+
+// Commit performs a two-phase commit. Each prepare function is called in
+// order and returns a commit function and a rollback function, either of
+// which may be nil. A prepare function reports failure by triggering an
+// escape. Rollback functions are registered with escape.Undo as they are
+// returned so that a failure rolls back everything prepared before it. Once
+// every prepare function has succeeded, all commit functions are called and
+// an escape is triggered with their joined errors if any failed. Only the
+// rollback functions of those whose commit failed remain registered, so
+// neither a failed commit nor a later escape rolls back committed work.
+func Commit(escape *Escape, prepares ...func() (commit func() error, rollback func())) {
+	type participant struct {
+		commit func() error
+		done   bool
+	}
+
+	participants := make([]*participant, 0, len(prepares))
+
+	for _, prepare := range prepares {
+		commit, rollback := prepare()
+
+		p := &participant{commit: commit}
+		participants = append(participants, p)
+
+		if rollback != nil {
+			escape.Undo(func() {
+				if !p.done {
+					rollback()
+				}
+			})
+		}
+	}
+
+	errs := make([]error, 0, len(participants))
+
+	for _, p := range participants {
+		var err error
+		if p.commit != nil {
+			err = p.commit()
+		}
+
+		p.done = err == nil
+
+		errs = append(errs, err)
+	}
+
+	escape.OnErrs(errs)
+}
@@ -0,0 +1,103 @@
+package handle_test
+
+import (
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleCommit() {
+	step := func(escape *handle.Escape, name string, err error) func() (func() error, func()) {
+		return func() (func() error, func()) {
+			fmt.Println("prepare", name)
+			escape.On(err)
+
+			commit := func() error {
+				fmt.Println("commit", name)
+				return nil
+			}
+
+			rollback := func() {
+				fmt.Println("rollback", name)
+			}
+
+			return commit, rollback
+		}
+	}
+
+	f := func(err error) (rerr error) {
+		escape, hatch := handle.Errorf(&rerr, "update")
+		defer hatch()
+
+		handle.Commit(escape,
+			step(escape, "db", nil),
+			step(escape, "index", nil),
+			step(escape, "cache", err),
+		)
+
+		return nil
+	}
+
+	fmt.Println(f(nil))
+	fmt.Println(f(errFailure))
+	// Output:
+	// prepare db
+	// prepare index
+	// prepare cache
+	// commit db
+	// commit index
+	// commit cache
+	// <nil>
+	// prepare db
+	// prepare index
+	// prepare cache
+	// rollback index
+	// rollback db
+	// update: failure
+}
+
+func ExampleCommit_commitFailed() {
+	step := func(name string, err error) func() (func() error, func()) {
+		return func() (func() error, func()) {
+			commit := func() error {
+				fmt.Println("commit", name)
+				return err
+			}
+
+			rollback := func() {
+				fmt.Println("rollback", name)
+			}
+
+			return commit, rollback
+		}
+	}
+
+	f := func(commitErr, laterErr error) (err error) {
+		escape, hatch := handle.Errorf(&err, "update")
+		defer hatch()
+
+		handle.Commit(escape,
+			step("db", nil),
+			step("index", commitErr),
+			step("cache", nil),
+		)
+
+		// Committed work is not rolled back by a later escape.
+		escape.On(laterErr)
+
+		return nil
+	}
+
+	fmt.Println(f(errFailure, nil))
+	fmt.Println(f(nil, errFailure))
+	// Output:
+	// commit db
+	// commit index
+	// commit cache
+	// rollback index
+	// update: failure
+	// commit db
+	// commit index
+	// commit cache
+	// update: failure
+}
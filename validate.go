@@ -0,0 +1,89 @@
+package handle
+
+import (
+	"strings"
+	"sync"
+)
+
+// FieldError reports a problem with the value of a field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every invalid field found by a Validator.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Error())
+	}
+
+	return "invalid " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		errs = append(errs, f)
+	}
+
+	return errs
+}
+
+// Validator records field-level errors and reports all of them at once.
+// It is safe for concurrent use.
+type Validator struct {
+	escape Escaper
+	mu     sync.Mutex
+	fields []*FieldError
+}
+
+// NewValidator returns a Validator that triggers an escape on escape when
+// Done is called if any invalid fields were recorded:
+//
+//	v := handle.NewValidator(escape)
+//	v.Field("email", checkEmail(req.Email))
+//	v.Field("age", checkAge(req.Age))
+//	v.Done()
+func NewValidator(escape Escaper) *Validator {
+	return &Validator{escape: escape}
+}
+
+// Field records err, if non-nil, as the problem with field. It reports
+// whether the field is valid.
+func (v *Validator) Field(field string, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.fields = append(v.fields, &FieldError{field, err})
+
+	return false
+}
+
+// Done triggers an escape with a *ValidationError, of the InvalidArgument
+// kind, if any invalid fields were recorded.
+func (v *Validator) Done() {
+	v.mu.Lock()
+	fields := v.fields
+	v.mu.Unlock()
+
+	if len(fields) > 0 {
+		v.escape.On(Tag(&ValidationError{fields}, InvalidArgument))
+	}
+}
@@ -0,0 +1,45 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleValidator() {
+	type request struct {
+		Email string
+		Age   int
+	}
+
+	check := func(req request) (err error) {
+		escape, hatch := handle.Errorf(&err, "create user")
+		defer hatch()
+
+		v := handle.NewValidator(escape)
+
+		if !strings.Contains(req.Email, "@") {
+			v.Field("email", errors.New("missing @"))
+		}
+
+		if req.Age < 0 {
+			v.Field("age", errors.New("negative"))
+		}
+
+		v.Done()
+
+		return nil
+	}
+
+	fmt.Println(check(request{"ann@example.com", 41}))
+
+	err := check(request{"bob", -1})
+	fmt.Println(err)
+	fmt.Println(handle.KindOf(err))
+	// Output:
+	// <nil>
+	// create user: invalid email: missing @; age: negative
+	// invalid argument
+}
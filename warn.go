@@ -0,0 +1,123 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Log sets the logger to which warnings are routed. Without it warnings go
+// to slog.Default().
+func Log(l Logger) Option {
+	return func(s *Escape) {
+		s.logger = l
+	}
+}
+
+// JoinWarnings makes the hatch join any warnings into the returned error,
+// even when the function would otherwise succeed. The warnings are joined
+// before handler functions run so they are wrapped along with the error.
+func JoinWarnings() Option {
+	return func(s *Escape) {
+		s.joinWarnings = true
+	}
+}
+
+// Best runs fn, a best-effort operation described by what. A failure never
+// triggers an escape. It is logged as a warning instead, making the choice
+// to ignore the error explicit without making the error invisible.
+func (s *Escape) Best(fn func() error, what string) {
+	if err := fn(); err != nil {
+		s.warn(what, err)
+	}
+}
+
+// Grace marks the point after which the function must not be reported as
+// a failure, for example because a response has already been sent. Errors
+// passed to On after Grace are recorded as warnings and logged, and On
+// returns without triggering an escape.
+func (s *Escape) Grace() {
+	s.grace = true
+}
+
+// ErrorAndWarnings is like Error but also binds warns, for functions with
+// a ([]Warning, error) style signature. Warnings recorded by the escape,
+// including those passed to Warn, are appended to *warns.
+func ErrorAndWarnings(err *error, warns *[]error, fns ...func()) (*Escape, func()) {
+	return With(err, Handlers(fns...), func(s *Escape) {
+		s.warnings = warns
+	})
+}
+
+// Warn records err, if it is not nil, as a warning without triggering an
+// escape.
+func (s *Escape) Warn(err error) {
+	if err != nil {
+		s.appendWarning(err)
+	}
+}
+
+// Warnings returns the errors recorded as warnings.
+func (s *Escape) Warnings() []error {
+	if s.warnings == nil {
+		return nil
+	}
+
+	return *s.warnings
+}
+
+func (s *Escape) appendWarning(err error) {
+	if s.warnings == nil {
+		s.warnings = &[]error{}
+	}
+
+	*s.warnings = append(*s.warnings, err)
+}
+
+func (s *Escape) record(msg string, err error) {
+	s.appendWarning(err)
+	s.warn(msg, err)
+}
+
+func (s *Escape) warn(msg string, err error, args ...interface{}) {
+	var l Logger = slog.Default()
+	if s.logger != nil {
+		l = s.logger
+	}
+
+	l.Log(context.Background(), s.level(err, slog.LevelWarn), msg, append([]interface{}{"error", err}, args...)...)
+}
+
+// Level logs errors matching any of targets, using errors.Is, at level.
+// Targets can be sentinel errors or Kinds. This keeps expected failures,
+// such as context.Canceled, out of error level logs:
+//
+//	handle.SetDefaults(
+//	    handle.Level(slog.LevelDebug, context.Canceled),
+//	    handle.Level(slog.LevelInfo, handle.InvalidArgument),
+//	)
+//
+// Levels apply to warnings and to failures logged by Trace. The first
+// matching rule wins.
+func Level(level slog.Level, targets ...error) Option {
+	return func(s *Escape) {
+		for _, target := range targets {
+			s.levels = append(s.levels, levelRule{target, level})
+		}
+	}
+}
+
+type levelRule struct {
+	target error
+	level  slog.Level
+}
+
+func (s *Escape) level(err error, fallback slog.Level) slog.Level {
+	for _, r := range s.levels {
+		if errors.Is(err, r.target) {
+			return r.level
+		}
+	}
+
+	return fallback
+}
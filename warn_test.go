@@ -0,0 +1,107 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/michaelmacinnis/handle"
+)
+
+func ExampleEscape_Best() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err, handle.Log(logger()))
+		defer hatch()
+
+		escape.Best(func() error {
+			_, err := fails("cache")
+			return err
+		}, "optional cache warm")
+
+		s, err := works("World!")
+		escape.On(err)
+
+		fmt.Println(s)
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// level=WARN msg="optional cache warm" error=failure
+	// Hello, World!
+	// <nil>
+}
+
+func ExampleEscape_Grace() {
+	var warnings []error
+
+	f := func() (err error) {
+		escape, hatch := handle.With(&err, handle.Log(logger()))
+		defer hatch()
+
+		s, err := works("World!")
+		escape.On(err)
+
+		fmt.Println("sent:", s)
+
+		escape.Grace()
+
+		_, err = fails("notify")
+		escape.On(err)
+
+		warnings = escape.Warnings()
+
+		return nil
+	}
+
+	fmt.Println(f())
+	fmt.Println(warnings)
+	// Output:
+	// sent: Hello, World!
+	// level=WARN msg="escape after grace" error=failure
+	// <nil>
+	// [failure]
+}
+
+func ExampleErrorAndWarnings() {
+	f := func(names ...string) (warns []error, err error) {
+		escape, hatch := handle.ErrorAndWarnings(&err, &warns)
+		defer hatch()
+
+		for _, name := range names {
+			if name == "" {
+				escape.Warn(errors.New("skipped empty name"))
+
+				continue
+			}
+
+			_, err = works(name)
+			escape.On(err)
+		}
+
+		return warns, nil
+	}
+
+	fmt.Println(f("a", "", "b", ""))
+	// Output: [skipped empty name skipped empty name] <nil>
+}
+
+func ExampleJoinWarnings() {
+	f := func() (err error) {
+		escape, hatch := handle.With(&err,
+			handle.JoinWarnings(),
+			handle.Wrapf("import"),
+		)
+		defer hatch()
+
+		escape.Warn(errors.New("row 3 skipped"))
+		escape.Warn(errors.New("row 7 skipped"))
+
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output:
+	// import: row 3 skipped
+	// row 7 skipped
+}